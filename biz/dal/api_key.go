@@ -0,0 +1,88 @@
+package dal
+
+import (
+	"confkeeper/biz/model"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// apiKeyTouchInterval 最后使用时间的更新间隔，避免每次请求都写库
+const apiKeyTouchInterval = time.Minute
+
+func CreateApiKey(key *model.ApiKey) error {
+	return DB.Create(key).Error
+}
+
+// GetApiKeyByHash 根据密钥摘要获取 API Key，不存在时返回 nil
+func GetApiKeyByHash(keyHash string) (*model.ApiKey, error) {
+	var key model.ApiKey
+	if err := DB.Where("key_hash = ?", keyHash).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetApiKeyByID 根据 ID 获取 API Key，不存在时返回 nil
+func GetApiKeyByID(id int) (*model.ApiKey, error) {
+	var key model.ApiKey
+	if err := DB.First(&key, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetApiKeysByUserID 获取服务账号的所有 API Key
+func GetApiKeysByUserID(userId int) ([]*model.ApiKey, error) {
+	var keys []*model.ApiKey
+	err := DB.Where("user_id = ?", userId).Order("id").Find(&keys).Error
+	return keys, err
+}
+
+// DeleteApiKey 吊销 API Key
+func DeleteApiKey(id int) error {
+	return DB.Delete(&model.ApiKey{}, "id = ?", id).Error
+}
+
+// TouchApiKey 记录 API Key 的最后使用时间和IP
+func TouchApiKey(key *model.ApiKey, ip string) error {
+	now := time.Now()
+	if key.LastUsedTime != nil && now.Sub(*key.LastUsedTime) < apiKeyTouchInterval && key.LastUsedIp == ip {
+		return nil
+	}
+
+	key.LastUsedTime = &now
+	key.LastUsedIp = ip
+	return DB.Model(key).Updates(map[string]interface{}{
+		"last_used_time": now,
+		"last_used_ip":   ip,
+	}).Error
+}
+
+// GetServiceAccountList 获取服务账号列表（分页）
+func GetServiceAccountList(pageSize int, offset int, username string) ([]*model.User, int64, error) {
+	var users []*model.User
+
+	query := DB.Model(&model.User{}).Where("is_service_account = ?", true)
+	if username != "" {
+		query = query.Where("username LIKE ?", "%"+username+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("id").Offset(offset).Limit(pageSize).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
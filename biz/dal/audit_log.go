@@ -0,0 +1,68 @@
+package dal
+
+import (
+	"confkeeper/biz/model"
+	"time"
+)
+
+// AuditLogFilter 审计日志查询条件，为空的条件不过滤
+type AuditLogFilter struct {
+	Actor      string
+	ClientIP   string
+	TargetType string
+	Action     string
+	// 按操作对象模糊查询
+	Target    string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// CreateAuditLog 记录审计日志
+func CreateAuditLog(log *model.AuditLog) error {
+	if log.CreateTime.IsZero() {
+		log.CreateTime = time.Now()
+	}
+	return DB.Create(log).Error
+}
+
+// GetAuditLogList 分页查询审计日志，按时间倒序
+func GetAuditLogList(filter *AuditLogFilter, pageSize, offset int) ([]*model.AuditLog, int64, error) {
+	var logs []*model.AuditLog
+	var total int64
+
+	query := DB.Model(&model.AuditLog{})
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.ClientIP != "" {
+		query = query.Where("client_ip = ?", filter.ClientIP)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Target != "" {
+		query = query.Where("target LIKE ?", "%"+filter.Target+"%")
+	}
+	if !filter.StartTime.IsZero() {
+		query = query.Where("create_time >= ?", filter.StartTime)
+	}
+	if !filter.EndTime.IsZero() {
+		query = query.Where("create_time < ?", filter.EndTime)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id DESC").Offset(offset).Limit(pageSize).Find(&logs).Error
+	return logs, total, err
+}
+
+// DeleteAuditLogsBefore 删除指定时间之前的审计日志，返回删除的条数
+func DeleteAuditLogsBefore(t time.Time) (int64, error) {
+	result := DB.Where("create_time < ?", t).Delete(&model.AuditLog{})
+	return result.RowsAffected, result.Error
+}
@@ -2,12 +2,18 @@ package dal
 
 import (
 	"confkeeper/biz/model"
+	"confkeeper/utils"
+	"confkeeper/utils/event_bus"
 	"errors"
 	"fmt"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrVersionConflict 发布时配置已被他人修改
+var ErrVersionConflict = errors.New("配置已被他人修改，请刷新后重试")
+
 func CreateConfigInfo(configInfo []*model.ConfigInfo) error {
 	for _, info := range configInfo {
 		exists, err := IsConfigInfoExistsWithTenant(info.DataID, info.GroupID, info.TenantID, info.Version)
@@ -17,8 +23,96 @@ func CreateConfigInfo(configInfo []*model.ConfigInfo) error {
 		if exists {
 			return fmt.Errorf("配置已存在: data_id=%s, group_id=%s", info.DataID, info.GroupID)
 		}
+		fillConfigDigest(info)
+	}
+	if err := DB.Create(&configInfo).Error; err != nil {
+		return err
+	}
+
+	publishConfigEvent(configInfo...)
+	return nil
+}
+
+// PublishConfigInfo 在同一事务内读取最大版本并以最大版本+1发布配置
+// baseVersion 不为空时要求当前最大版本等于该值(0表示配置不存在)，casMd5 不为空时要求当前最新内容的md5等于该值
+// 条件不满足或并发发布冲突时返回 ErrVersionConflict
+func PublishConfigInfo(info *model.ConfigInfo, baseVersion *int, casMd5 string) error {
+	return publishConfigInfo(info, baseVersion, casMd5, nil)
+}
+
+// publishConfigInfo 发布配置，afterCreate 不为空时在同一事务内执行
+func publishConfigInfo(info *model.ConfigInfo, baseVersion *int, casMd5 string, afterCreate func(tx *gorm.DB) error) error {
+	fillConfigDigest(info)
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		// 锁定当前最新版本，sqlite 会忽略行锁
+		var latest model.ConfigInfo
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("data_id = ? AND group_id = ? AND tenant_id = ?", info.DataID, info.GroupID, info.TenantID).
+			Order("version DESC").
+			Limit(1).
+			Find(&latest)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		var latestVersion int
+		var latestMd5 string
+		if result.RowsAffected > 0 {
+			latestVersion = latest.Version
+			latestMd5 = latest.Md5
+		}
+
+		if baseVersion != nil && *baseVersion != latestVersion {
+			return ErrVersionConflict
+		}
+		if casMd5 != "" && casMd5 != latestMd5 {
+			return ErrVersionConflict
+		}
+
+		info.Version = latestVersion + 1
+		if err := tx.Create(info).Error; err != nil {
+			return err
+		}
+		if afterCreate != nil {
+			return afterCreate(tx)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			return err
+		}
+		// 并发发布时唯一索引冲突，统一转换为版本冲突
+		if exists, existsErr := IsConfigInfoExistsWithTenant(info.DataID, info.GroupID, info.TenantID, info.Version); existsErr == nil && exists {
+			return ErrVersionConflict
+		}
+		return err
+	}
+
+	publishConfigEvent(info)
+	return nil
+}
+
+// fillConfigDigest 每个版本都记录内容摘要
+func fillConfigDigest(info *model.ConfigInfo) {
+	info.Md5 = utils.MD5(info.Content)
+	info.Sha256 = utils.SHA256(info.Content)
+}
+
+// publishConfigEvent 通知监听者配置已产生新版本
+func publishConfigEvent(configInfo ...*model.ConfigInfo) {
+	for _, info := range configInfo {
+		event_bus.Publish(event_bus.ConfigEvent{
+			Type:     event_bus.EventPublish,
+			TenantID: info.TenantID,
+			GroupID:  info.GroupID,
+			DataID:   info.DataID,
+			Version:  info.Version,
+			Md5:      info.Md5,
+			Author:   info.Author,
+		})
 	}
-	return DB.Create(&configInfo).Error
 }
 
 func GetConfigInfoByID(ConfigInfoID string) (*model.ConfigInfo, error) {
@@ -54,6 +148,20 @@ func GetMaxVersionByDataIdGroupAndTenant(dataId string, groupId string, tenantId
 	return maxVersion, err
 }
 
+// GetConfigInfoByVersion 获取指定data_id、group_id、tenant_id的指定版本配置
+func GetConfigInfoByVersion(dataId string, groupId string, tenantId string, version int) (*model.ConfigInfo, error) {
+	var configInfo model.ConfigInfo
+	err := DB.Where("data_id = ? AND group_id = ? AND tenant_id = ? AND version = ?", dataId, groupId, tenantId, version).
+		First(&configInfo).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // 版本不存在时返回 nil
+		}
+		return nil, err // 其他错误
+	}
+	return &configInfo, nil
+}
+
 // GetConfigInfoByDataIdAndGroupWithMaxVersion 获取指定data_id和group_id的最大版本配置
 func GetConfigInfoByDataIdAndGroupWithMaxVersion(dataId string, groupId string, tenantId string) (*model.ConfigInfo, error) {
 	var configInfo model.ConfigInfo
@@ -74,12 +182,31 @@ func GetConfigInfoByDataIdAndGroupWithMaxVersion(dataId string, groupId string,
 
 // DeleteConfigInfo 根据data_id和group_id删除命名空间所有版本配置
 func DeleteConfigInfo(tentantId string, dataId string, groupId string) error {
-	return DB.Where("data_id = ? AND group_id = ? AND tenant_id = ?", dataId, groupId, tentantId).
-		Delete(&model.ConfigInfo{}).Error
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("data_id = ? AND group_id = ? AND tenant_id = ?", dataId, groupId, tentantId).
+			Delete(&model.ConfigInfo{}).Error; err != nil {
+			return err
+		}
+		// 配置删除后灰度版本一并删除
+		return tx.Where("data_id = ? AND group_id = ? AND tenant_id = ?", dataId, groupId, tentantId).
+			Delete(&model.ConfigInfoBeta{}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	event_bus.Publish(event_bus.ConfigEvent{
+		Type:     event_bus.EventDelete,
+		TenantID: tentantId,
+		GroupID:  groupId,
+		DataID:   dataId,
+	})
+	return nil
 }
 
 // GetConfigInfoListWithMaxVersion 获取配置列表，只返回每个data_id和group_id组合的最大版本
-func GetConfigInfoListWithMaxVersion(pageSize, offset int, dataId, groupId, Type, tenantId string) ([]*model.ConfigInfo, int64, error) {
+// scope 为用户在该命名空间内可读的范围，为 nil 时不限制
+func GetConfigInfoListWithMaxVersion(pageSize, offset int, dataId, groupId, Type, tenantId string, scope *ResourceScope) ([]*model.ConfigInfo, int64, error) {
 	var configInfos []*model.ConfigInfo
 
 	// where 条件组装
@@ -94,6 +221,10 @@ func GetConfigInfoListWithMaxVersion(pageSize, offset int, dataId, groupId, Type
 		conditions += " AND group_id LIKE ?"
 		args = append(args, "%"+groupId+"%")
 	}
+	if condition, conditionArgs := scopeCondition(scope); condition != "" {
+		conditions += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
 
 	// 子查询1：排序用（version=1 的 id）
 	sorterSubQuery := DB.
@@ -149,6 +280,29 @@ func GetConfigInfoListWithMaxVersion(pageSize, offset int, dataId, groupId, Type
 	return configInfos, total, nil
 }
 
+// GetLatestConfigInfosByTenant 获取命名空间下每个data_id和group_id组合的最大版本，scope 为 nil 时不限制
+func GetLatestConfigInfosByTenant(tenantId string, scope *ResourceScope) ([]*model.ConfigInfo, error) {
+	var configInfos []*model.ConfigInfo
+
+	latestVersionSubQuery := DB.
+		Table("config_info").
+		Select("data_id, group_id, MAX(version) AS max_version").
+		Where("tenant_id = ?", tenantId).
+		Group("data_id, group_id")
+	if condition, args := scopeCondition(scope); condition != "" {
+		latestVersionSubQuery = latestVersionSubQuery.Where(condition, args...)
+	}
+
+	err := DB.
+		Table("config_info AS ci").
+		Select("ci.*").
+		Joins("JOIN (?) AS lv ON lv.data_id = ci.data_id AND lv.group_id = ci.group_id AND lv.max_version = ci.version", latestVersionSubQuery).
+		Where("ci.tenant_id = ?", tenantId).
+		Order("ci.group_id, ci.data_id").
+		Find(&configInfos).Error
+	return configInfos, err
+}
+
 // GetAllVersionsByDataIdAndGroup 根据data_id和group_id查询所有版本，按版本倒序返回
 func GetAllVersionsByDataIdAndGroup(dataId string, groupId string) ([]*model.ConfigInfo, error) {
 	var configInfos []*model.ConfigInfo
@@ -179,12 +333,13 @@ func IsConfigInfoExistsByTenantId(tenantId string) (bool, error) {
 	return count > 0, err
 }
 
-// ClearOldConfigVersions 清理除最大版本外的旧版本配置
+// ClearOldConfigVersions 清理除最大版本外的旧版本配置，返回删除的行数
 // 如果最大版本为1，则不删除任何记录
-func ClearOldConfigVersions() error {
-	return DB.Transaction(func(tx *gorm.DB) error {
+func ClearOldConfigVersions() (int64, error) {
+	var deleted int64
+	err := DB.Transaction(func(tx *gorm.DB) error {
 		// 直接用子查询找出最大 version，然后删除小于它的
-		err := tx.Exec(`
+		result := tx.Exec(`
 			DELETE FROM config_info ci
 			USING (
 				SELECT data_id, group_id, tenant_id, MAX(version) AS max_ver
@@ -196,10 +351,12 @@ func ClearOldConfigVersions() error {
 			  AND ci.group_id = mv.group_id
 			  AND ci.tenant_id = mv.tenant_id
 			  AND ci.version < mv.max_ver
-		`).Error
-		if err != nil {
-			return err
+		`)
+		if result.Error != nil {
+			return result.Error
 		}
+		deleted = result.RowsAffected
 		return nil
 	})
+	return deleted, err
 }
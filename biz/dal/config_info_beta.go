@@ -0,0 +1,111 @@
+package dal
+
+import (
+	"confkeeper/biz/model"
+	"confkeeper/utils"
+	"confkeeper/utils/event_bus"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SaveConfigInfoBeta 保存灰度配置，已存在灰度版本时覆盖内容和规则
+func SaveConfigInfoBeta(beta *model.ConfigInfoBeta) error {
+	beta.Md5 = utils.MD5(beta.Content)
+	beta.Sha256 = utils.SHA256(beta.Content)
+	beta.UpdateTime = time.Now()
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		var existing model.ConfigInfoBeta
+		result := tx.Where("data_id = ? AND group_id = ? AND tenant_id = ?", beta.DataID, beta.GroupID, beta.TenantID).
+			Limit(1).
+			Find(&existing)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return tx.Create(beta).Error
+		}
+
+		beta.ID = existing.ID
+		beta.CreateTime = existing.CreateTime
+		return tx.Model(beta).
+			Select("content", "md5", "sha256", "type", "beta_ips", "beta_labels", "author", "update_time").
+			Updates(beta).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	publishBetaEvent(beta)
+	return nil
+}
+
+// GetConfigInfoBeta 获取配置的灰度版本，不存在时返回 nil
+func GetConfigInfoBeta(dataId string, groupId string, tenantId string) (*model.ConfigInfoBeta, error) {
+	var beta model.ConfigInfoBeta
+	err := DB.Where("data_id = ? AND group_id = ? AND tenant_id = ?", dataId, groupId, tenantId).First(&beta).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &beta, nil
+}
+
+// GetConfigInfoBetaListByTenant 获取命名空间下所有灰度配置
+func GetConfigInfoBetaListByTenant(tenantId string) ([]*model.ConfigInfoBeta, error) {
+	var betas []*model.ConfigInfoBeta
+	err := DB.Where("tenant_id = ?", tenantId).Find(&betas).Error
+	return betas, err
+}
+
+// DeleteConfigInfoBeta 终止灰度，灰度客户端回到正式版本
+func DeleteConfigInfoBeta(beta *model.ConfigInfoBeta) error {
+	if err := DB.Delete(&model.ConfigInfoBeta{}, "id = ?", beta.ID).Error; err != nil {
+		return err
+	}
+
+	publishBetaEvent(beta)
+	return nil
+}
+
+// PromoteConfigInfoBeta 将灰度内容发布为新的最大版本并删除灰度配置
+// 灰度内容在读取后被修改或删除时返回 ErrVersionConflict
+func PromoteConfigInfoBeta(beta *model.ConfigInfoBeta, author string) (*model.ConfigInfo, error) {
+	info := &model.ConfigInfo{
+		DataID:   beta.DataID,
+		GroupID:  beta.GroupID,
+		Content:  beta.Content,
+		TenantID: beta.TenantID,
+		Type:     beta.Type,
+		Author:   author,
+	}
+
+	err := publishConfigInfo(info, nil, "", func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND md5 = ?", beta.ID, beta.Md5).Delete(&model.ConfigInfoBeta{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// publishBetaEvent 灰度变更同样通知监听者，由监听者按客户端重新比对
+func publishBetaEvent(beta *model.ConfigInfoBeta) {
+	event_bus.Publish(event_bus.ConfigEvent{
+		Type:     event_bus.EventBeta,
+		TenantID: beta.TenantID,
+		GroupID:  beta.GroupID,
+		DataID:   beta.DataID,
+	})
+}
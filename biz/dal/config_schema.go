@@ -0,0 +1,74 @@
+package dal
+
+import (
+	"confkeeper/biz/model"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func CreateConfigSchema(schema *model.ConfigSchema) error {
+	return DB.Create(schema).Error
+}
+
+func UpdateConfigSchema(schema *model.ConfigSchema) error {
+	schema.UpdateTime = time.Now()
+	return DB.Model(schema).Select("content", "author", "update_time").Updates(schema).Error
+}
+
+func DeleteConfigSchema(id uint) error {
+	return DB.Delete(&model.ConfigSchema{}, "id = ?", id).Error
+}
+
+func IsConfigSchemaExists(dataId string, groupId string, tenantId string) (bool, error) {
+	var count int64
+	err := DB.Model(&model.ConfigSchema{}).Where("data_id = ? AND group_id = ? AND tenant_id = ?", dataId, groupId, tenantId).Count(&count).Error
+	return count > 0, err
+}
+
+func GetConfigSchemaByID(id uint) (*model.ConfigSchema, error) {
+	var schema model.ConfigSchema
+	if err := DB.First(&schema, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// GetConfigSchema 获取配置绑定的 schema，未绑定时返回 nil
+func GetConfigSchema(dataId string, groupId string, tenantId string) (*model.ConfigSchema, error) {
+	var schema model.ConfigSchema
+	err := DB.Where("data_id = ? AND group_id = ? AND tenant_id = ?", dataId, groupId, tenantId).First(&schema).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schema, nil
+}
+
+func GetConfigSchemaList(pageSize, offset int, dataId, groupId, tenantId string, scope *ResourceScope) ([]*model.ConfigSchema, int64, error) {
+	var schemas []*model.ConfigSchema
+	query := DB.Model(&model.ConfigSchema{}).Where("tenant_id = ?", tenantId)
+	if condition, args := scopeCondition(scope); condition != "" {
+		query = query.Where(condition, args...)
+	}
+	if dataId != "" {
+		query = query.Where("data_id LIKE ?", "%"+dataId+"%")
+	}
+	if groupId != "" {
+		query = query.Where("group_id LIKE ?", "%"+groupId+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id").Limit(pageSize).Offset(offset).Find(&schemas).Error
+	return schemas, total, err
+}
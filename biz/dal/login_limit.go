@@ -0,0 +1,92 @@
+package dal
+
+import (
+	"confkeeper/biz/model"
+	"confkeeper/utils/config"
+	"confkeeper/utils/login_limit"
+	"errors"
+	"time"
+
+	"github.com/gookit/slog"
+	"gorm.io/gorm"
+)
+
+// CheckLoginLimit 校验密码前检查用户名及来源IP是否因登录失败次数过多被锁定
+func CheckLoginLimit(username string, ip string) error {
+	if remaining := login_limit.IPLockedFor(ip); remaining > 0 {
+		return login_limit.LockedError(remaining)
+	}
+
+	var user model.User
+	err := DB.Select("id", "locked_until").Where("username = ?", username).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.IsLocked() {
+		return login_limit.LockedError(time.Until(*user.LockedUntil))
+	}
+	return nil
+}
+
+// RecordLoginFailure 记录一次登录失败，连续失败达到次数后锁定用户名及来源IP
+func RecordLoginFailure(username string, ip string) {
+	login_limit.RecordIPFailure(ip)
+
+	maxAttempts := config.Cfg.LoginLimit.MaxAttempts
+	if maxAttempts <= 0 {
+		return
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		err := tx.Select("id", "failed_login_count", "last_failed_login_time").Where("username = ?", username).First(&user).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		now := time.Now()
+		failures := user.FailedLoginCount
+		if user.LastFailedLoginTime == nil || now.Sub(*user.LastFailedLoginTime) > login_limit.ResetTime() {
+			failures = 0
+		}
+		failures++
+
+		updates := map[string]interface{}{
+			"failed_login_count":     failures,
+			"last_failed_login_time": now,
+		}
+		if lockDuration := login_limit.LockDuration(failures, maxAttempts); lockDuration > 0 {
+			updates["locked_until"] = now.Add(lockDuration)
+			slog.Warnf("用户 %s 连续登录失败 %d 次，锁定至 %s", username, failures, now.Add(lockDuration).Format(time.DateTime))
+		}
+		return tx.Model(&user).Updates(updates).Error
+	})
+	if err != nil {
+		slog.Warnf("记录用户 %s 登录失败失败: %v", username, err)
+	}
+}
+
+// RecordLoginSuccess 登录成功后清零用户的连续失败次数
+func RecordLoginSuccess(user *model.User) {
+	if user.FailedLoginCount == 0 && user.LockedUntil == nil {
+		return
+	}
+	if err := UnlockUser(user); err != nil {
+		slog.Warnf("清零用户 %s 登录失败次数失败: %v", user.Username, err)
+	}
+}
+
+// UnlockUser 解除用户锁定并清零连续失败次数
+func UnlockUser(user *model.User) error {
+	return DB.Model(user).Updates(map[string]interface{}{
+		"failed_login_count":     0,
+		"last_failed_login_time": nil,
+		"locked_until":           nil,
+	}).Error
+}
@@ -2,14 +2,20 @@ package dal
 
 import (
 	"confkeeper/biz/model"
+	"confkeeper/utils"
+	"slices"
+	"strings"
+
+	"github.com/gookit/slog"
 )
 
-// AddRolePermission 为角色添加权限
-func AddRolePermission(role, resource, action string) error {
+// AddRolePermission 为角色添加权限，effect 为 allow 或 deny
+func AddRolePermission(role, resource, action, effect string) error {
 	permission := &model.Permissions{
 		Role:     role,
 		Resource: resource,
 		Action:   action,
+		Effect:   effect,
 	}
 	return DB.Create(permission).Error
 }
@@ -64,8 +70,134 @@ func GetUserRoles(username string) ([]string, error) {
 	return roleNames, nil
 }
 
-// GetUserNamespacePermissions 获取用户对指定命名空间的所有权限
+// 读取需要 r 或 rw 权限，写入需要 rw 或 w 权限；拒绝规则的操作在其中时同样生效
+var (
+	ReadActions  = []string{"r", "rw"}
+	WriteActions = []string{"rw", "w"}
+)
+
+// PermissionDecision 权限判定结果
+type PermissionDecision struct {
+	Allowed bool
+	// 与配置和操作匹配的允许规则和拒绝规则，只要有一条拒绝规则就不允许
+	AllowRules []*model.Permissions
+	DenyRules  []*model.Permissions
+}
+
+// ResourceScope 用户在命名空间内可访问的范围，nil 表示不限制(管理员)
+type ResourceScope struct {
+	Allow []utils.Resource
+	Deny  []utils.Resource
+}
+
+// IsEmpty 命名空间内没有任何可访问的配置
+func (scope *ResourceScope) IsEmpty() bool {
+	if scope == nil {
+		return false
+	}
+	return len(scope.Allow) == 0 || slices.ContainsFunc(scope.Deny, utils.Resource.MatchAll)
+}
+
+// Covers 是否可以访问 group、dataId 通配符范围内的所有配置：某个允许资源包含整个范围，且没有拒绝资源与范围重叠
+func (scope *ResourceScope) Covers(tenant string, group string, dataId string) bool {
+	if scope == nil {
+		return true
+	}
+	covered := slices.ContainsFunc(scope.Allow, func(r utils.Resource) bool { return r.Covers(tenant, group, dataId) })
+	overlapped := slices.ContainsFunc(scope.Deny, func(r utils.Resource) bool { return r.Overlaps(tenant, group, dataId) })
+	return covered && !overlapped
+}
+
+// GetUserNamespacePermissions 获取用户对指定命名空间的所有权限，包括只对命名空间下部分配置生效的权限
 func GetUserNamespacePermissions(username string, namespace string) ([]*model.Permissions, error) {
+	permissions, err := getUserPermissions(username)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Permissions, 0, len(permissions))
+	for _, permission := range permissions {
+		resource, err := utils.ParseResource(permission.Resource)
+		if err == nil && resource.MatchTenant(namespace) {
+			result = append(result, permission)
+		}
+	}
+	return result, nil
+}
+
+// HasNamespacePermission 检查用户是否有指定命名空间的权限，只对命名空间下部分配置生效的权限也算在内
+func HasNamespacePermission(username string, namespace string, action string) (bool, error) {
+	scope, err := GetUserNamespaceScope(username, namespace, action)
+	if err != nil {
+		return false, err
+	}
+	return !scope.IsEmpty(), nil
+}
+
+// HasConfigPermission 检查用户是否有指定配置的权限
+func HasConfigPermission(username string, tenant string, group string, dataId string, actions ...string) (bool, error) {
+	decision, err := ExplainConfigPermission(username, tenant, group, dataId, actions...)
+	if err != nil {
+		return false, err
+	}
+	return decision.Allowed, nil
+}
+
+// ExplainConfigPermission 判定用户对指定配置是否有 actions 中任一权限，并返回匹配的规则
+func ExplainConfigPermission(username string, tenant string, group string, dataId string, actions ...string) (*PermissionDecision, error) {
+	permissions, err := getUserPermissions(username, actions...)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := new(PermissionDecision)
+	for _, permission := range permissions {
+		resource, err := utils.ParseResource(permission.Resource)
+		if err != nil {
+			slog.Warnf("忽略角色 %s 格式错误的权限: %v", permission.Role, err)
+			continue
+		}
+		if !resource.Match(tenant, group, dataId) {
+			continue
+		}
+		if permission.IsDeny() {
+			decision.DenyRules = append(decision.DenyRules, permission)
+		} else {
+			decision.AllowRules = append(decision.AllowRules, permission)
+		}
+	}
+	decision.Allowed = len(decision.AllowRules) > 0 && len(decision.DenyRules) == 0
+	return decision, nil
+}
+
+// GetUserNamespaceScope 获取用户在指定命名空间内 actions 中任一权限的允许和拒绝资源
+func GetUserNamespaceScope(username string, namespace string, actions ...string) (*ResourceScope, error) {
+	permissions, err := getUserPermissions(username, actions...)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := &ResourceScope{Allow: []utils.Resource{}}
+	for _, permission := range permissions {
+		resource, err := utils.ParseResource(permission.Resource)
+		if err != nil {
+			slog.Warnf("忽略角色 %s 格式错误的权限: %v", permission.Role, err)
+			continue
+		}
+		if !resource.MatchTenant(namespace) {
+			continue
+		}
+		if permission.IsDeny() {
+			scope.Deny = append(scope.Deny, resource)
+		} else {
+			scope.Allow = append(scope.Allow, resource)
+		}
+	}
+	return scope, nil
+}
+
+// getUserPermissions 获取用户所有角色的权限，actions 不为空时只返回这些操作的权限
+func getUserPermissions(username string, actions ...string) ([]*model.Permissions, error) {
 	roles, err := GetUserRoles(username)
 	if err != nil {
 		return nil, err
@@ -76,24 +208,84 @@ func GetUserNamespacePermissions(username string, namespace string) ([]*model.Pe
 	}
 
 	var permissions []*model.Permissions
-	err = DB.Where("role IN (?) AND resource = ?", roles, namespace).Find(&permissions).Error
+	query := DB.Where("role IN (?)", roles)
+	if len(actions) > 0 {
+		query = query.Where("action IN (?)", actions)
+	}
+	err = query.Find(&permissions).Error
 	return permissions, err
 }
 
-// HasNamespacePermission 检查用户是否有指定命名空间的权限
-func HasNamespacePermission(username string, namespace string, action string) (bool, error) {
-	roles, err := GetUserRoles(username)
-	if err != nil {
-		return false, err
+// scopeCondition 把可访问范围转换为 group_id 和 data_id 的查询条件：满足任一允许资源且不满足任何拒绝资源
+// 调用方需要先确认资源属于要查询的命名空间；scope 为 nil 或不需要过滤时返回空条件
+func scopeCondition(scope *ResourceScope) (string, []interface{}) {
+	if scope == nil {
+		return "", nil
+	}
+	if scope.IsEmpty() {
+		return "1 = 0", nil
 	}
 
-	if len(roles) == 0 {
-		return false, nil
+	var conditions []string
+	var args []interface{}
+	if condition, conditionArgs := resourceCondition(scope.Allow); condition != "" {
+		conditions = append(conditions, condition)
+		args = append(args, conditionArgs...)
 	}
+	if condition, conditionArgs := resourceCondition(scope.Deny); condition != "" {
+		conditions = append(conditions, "NOT "+condition)
+		args = append(args, conditionArgs...)
+	}
+	return strings.Join(conditions, " AND "), args
+}
 
-	var count int64
-	err = DB.Model(&model.Permissions{}).
-		Where("role IN (?) AND resource = ? AND action = ?", roles, namespace, action).
-		Count(&count).Error
-	return count > 0, err
+// resourceCondition 满足任一资源的查询条件，包含整个命名空间的资源时返回空条件
+func resourceCondition(resources []utils.Resource) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	for _, resource := range resources {
+		if resource.MatchAll() {
+			return "", nil
+		}
+
+		var parts []string
+		for _, item := range []struct{ column, pattern string }{
+			{"group_id", resource.Group},
+			{"data_id", resource.DataId},
+		} {
+			switch {
+			case item.pattern == utils.ResourceWildcard:
+			case utils.HasWildcard(item.pattern):
+				parts = append(parts, item.column+" LIKE ? ESCAPE '!'")
+				args = append(args, globToLike(item.pattern))
+			default:
+				parts = append(parts, item.column+" = ?")
+				args = append(args, item.pattern)
+			}
+		}
+		conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// globToLike 通配符转换为 LIKE 模式，使用各数据库都支持的 ! 作为转义字符
+func globToLike(pattern string) string {
+	var b strings.Builder
+	for _, r := range pattern {
+		switch r {
+		case '!', '%', '_':
+			b.WriteRune('!')
+			b.WriteRune(r)
+		case '*':
+			b.WriteRune('%')
+		case '?':
+			b.WriteRune('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
 }
@@ -1,7 +1,9 @@
 package dal
 
 import (
+	"confkeeper/biz/model"
 	"fmt"
+	"slices"
 )
 
 // PermissionService 权限服务
@@ -34,6 +36,15 @@ func (s *permissionService) CheckNamespacePermission(username string, namespace
 	return hasPermission, nil
 }
 
+// IsAdmin 检查用户是否为管理员，管理员拥有所有命名空间的权限
+func (s *permissionService) IsAdmin(userId int) (bool, error) {
+	isAdmin, err := IsAdminUser(userId)
+	if err != nil {
+		return false, fmt.Errorf("查询管理员失败: %v", err)
+	}
+	return isAdmin, nil
+}
+
 // GetUserRoles 获取用户的所有角色
 func (s *permissionService) GetUserRoles(username string) ([]string, error) {
 	return GetUserRoles(username)
@@ -55,9 +66,12 @@ func (s *permissionService) GetUserNamespacePermissions(username string, namespa
 		return nil, fmt.Errorf("查询权限失败: %v", err)
 	}
 
-	actions := make([]string, len(permissions))
-	for i, perm := range permissions {
-		actions[i] = perm.Action
+	// 拒绝规则不授予操作权限
+	actions := make([]string, 0, len(permissions))
+	for _, perm := range permissions {
+		if !perm.IsDeny() {
+			actions = append(actions, perm.Action)
+		}
 	}
 
 	return actions, nil
@@ -81,3 +95,76 @@ func (s *permissionService) CheckNamespaceWritePermission(username string, names
 	// 再检查w权限
 	return s.CheckNamespacePermission(username, namespace, "w")
 }
+
+// CheckConfigReadPermission 检查用户是否有指定配置的读取权限（r或rw）
+func (s *permissionService) CheckConfigReadPermission(username string, tenant string, group string, dataId string) (bool, error) {
+	hasPermission, err := HasConfigPermission(username, tenant, group, dataId, ReadActions...)
+	if err != nil {
+		return false, fmt.Errorf("查询权限失败: %v", err)
+	}
+	return hasPermission, nil
+}
+
+// CheckConfigWritePermission 检查用户是否有指定配置的写权限（rw或w）
+func (s *permissionService) CheckConfigWritePermission(username string, tenant string, group string, dataId string) (bool, error) {
+	hasPermission, err := HasConfigPermission(username, tenant, group, dataId, WriteActions...)
+	if err != nil {
+		return false, fmt.Errorf("查询权限失败: %v", err)
+	}
+	return hasPermission, nil
+}
+
+// CheckConfigRangeWritePermission 检查用户是否有 group、dataId 通配符范围内所有配置的写权限（rw或w）
+func (s *permissionService) CheckConfigRangeWritePermission(username string, tenant string, group string, dataId string) (bool, error) {
+	scope, err := GetUserNamespaceScope(username, tenant, WriteActions...)
+	if err != nil {
+		return false, fmt.Errorf("查询权限失败: %v", err)
+	}
+	return scope.Covers(tenant, group, dataId), nil
+}
+
+// GetNamespaceReadScope 获取用户在命名空间内可以读取的范围（r或rw），用于过滤列表
+func (s *permissionService) GetNamespaceReadScope(username string, namespace string) (*ResourceScope, error) {
+	scope, err := GetUserNamespaceScope(username, namespace, ReadActions...)
+	if err != nil {
+		return nil, fmt.Errorf("查询权限失败: %v", err)
+	}
+	return scope, nil
+}
+
+// ExplainConfigPermission 判定用户对指定配置的权限并返回匹配的规则，action 为 r、w 或 rw(需要同时有读写权限)
+func (s *permissionService) ExplainConfigPermission(username string, tenant string, group string, dataId string, action string) (*PermissionDecision, error) {
+	var checks [][]string
+	switch action {
+	case "r":
+		checks = [][]string{ReadActions}
+	case "w":
+		checks = [][]string{WriteActions}
+	case "rw":
+		checks = [][]string{ReadActions, WriteActions}
+	default:
+		return nil, fmt.Errorf("不支持的操作: %s", action)
+	}
+
+	result := &PermissionDecision{Allowed: true}
+	for _, actions := range checks {
+		decision, err := ExplainConfigPermission(username, tenant, group, dataId, actions...)
+		if err != nil {
+			return nil, fmt.Errorf("查询权限失败: %v", err)
+		}
+		result.Allowed = result.Allowed && decision.Allowed
+		result.AllowRules = appendRules(result.AllowRules, decision.AllowRules)
+		result.DenyRules = appendRules(result.DenyRules, decision.DenyRules)
+	}
+	return result, nil
+}
+
+// appendRules 合并规则，rw 同时属于读和写两次判定，只保留一次
+func appendRules(rules []*model.Permissions, more []*model.Permissions) []*model.Permissions {
+	for _, rule := range more {
+		if !slices.ContainsFunc(rules, func(r *model.Permissions) bool { return *r == *rule }) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
@@ -2,8 +2,10 @@ package dal
 
 import (
 	"confkeeper/biz/model"
+	"slices"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // CreateRole 为用户添加角色
@@ -11,6 +13,51 @@ func CreateRole(roles []*model.Roles) error {
 	return DB.Create(roles).Error
 }
 
+// SyncUserRoles 按外部身份源同步用户角色，返回新增和移除的角色
+// managedRoles 为由外部身份源管理的全部角色，其中不在 roles 中的会被移除，其余角色不受影响
+func SyncUserRoles(username string, roles []string, managedRoles []string) (added []string, removed []string, err error) {
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		var existing []string
+		if err := tx.Model(&model.Roles{}).
+			Where("username = ? AND role IN ?", username, managedRoles).
+			Pluck("role", &existing).Error; err != nil {
+			return err
+		}
+
+		held := make(map[string]bool, len(existing))
+		for _, role := range existing {
+			held[role] = true
+			if !slices.Contains(roles, role) {
+				removed = append(removed, role)
+			}
+		}
+		for _, role := range roles {
+			if !held[role] {
+				held[role] = true
+				added = append(added, role)
+			}
+		}
+
+		if len(removed) > 0 {
+			if err := tx.Where("username = ? AND role IN ?", username, removed).Delete(&model.Roles{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(added) == 0 {
+			return nil
+		}
+		records := make([]*model.Roles, 0, len(added))
+		for _, role := range added {
+			records = append(records, &model.Roles{Username: username, Role: role})
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&records).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return added, removed, nil
+}
+
 // IsRoleExistsInRoles 检查角色是否存在（基于角色表）
 func IsRoleExistsInRoles(role string) (bool, error) {
 	var count int64
@@ -0,0 +1,116 @@
+package dal
+
+import (
+	"confkeeper/biz/model"
+	"confkeeper/utils/session"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SessionStore 基于数据库的会话存储，多副本共享同一个数据库时会话互通
+var SessionStore = &sessionStore{}
+
+type sessionStore struct{}
+
+func (s *sessionStore) Add(userid int, sessionID string, expireTime time.Time, maxSessions int) error {
+	now := time.Now()
+	return DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&model.UserSession{
+			UserID:     userid,
+			TokenHash:  session.HashToken(sessionID),
+			ExpireTime: expireTime,
+			CreateTime: now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		// 按创建顺序保留最新的 maxSessions 个会话
+		var ids []uint
+		if err := tx.Model(&model.UserSession{}).Where("user_id = ?", userid).Order("id DESC").Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) > maxSessions {
+			if err := tx.Where("id IN ?", ids[maxSessions:]).Delete(&model.UserSession{}).Error; err != nil {
+				return err
+			}
+		}
+
+		// 清理已过期的会话，过期 token 本身无法通过校验，不影响淘汰结果
+		return tx.Where("user_id = ? AND expire_time < ?", userid, now).Delete(&model.UserSession{}).Error
+	})
+}
+
+func (s *sessionStore) Exists(userid int, sessionID string) (bool, error) {
+	var count int64
+	err := DB.Model(&model.UserSession{}).
+		Where("user_id = ? AND token_hash = ?", userid, session.HashToken(sessionID)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (s *sessionStore) Remove(userid int, sessionID string) error {
+	return DB.Where("user_id = ? AND token_hash = ?", userid, session.HashToken(sessionID)).Delete(&model.UserSession{}).Error
+}
+
+func (s *sessionStore) RemoveAll(userid int) error {
+	return DB.Where("user_id = ?", userid).Delete(&model.UserSession{}).Error
+}
+
+func (s *sessionStore) Revoke(sessionID string, expireTime time.Time) error {
+	return s.addRevokedToken(&model.RevokedToken{
+		Jti:        sessionID,
+		RevokeTime: time.Now(),
+		ExpireTime: expireTime,
+	})
+}
+
+func (s *sessionStore) RevokeUser(userid int, revokeTime time.Time, expireTime time.Time) error {
+	return s.addRevokedToken(&model.RevokedToken{
+		UserID:     userid,
+		RevokeTime: revokeTime,
+		ExpireTime: expireTime,
+	})
+}
+
+func (s *sessionStore) IsRevoked(userid int, sessionID string, issuedAt time.Time) (bool, error) {
+	var count int64
+	err := DB.Model(&model.RevokedToken{}).
+		Where("expire_time >= ?", time.Now()).
+		Where(DB.Where("jti = ? AND jti <> ''", sessionID).
+			Or("jti = '' AND user_id = ? AND revoke_time >= ?", userid, issuedAt)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (s *sessionStore) SaveRefresh(sessionID string, jti string, expireTime time.Time) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expire_time < ?", time.Now()).Delete(&model.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.RefreshToken{
+			SessionHash: session.HashToken(sessionID),
+			Jti:         jti,
+			ExpireTime:  expireTime,
+		}).Error
+	})
+}
+
+func (s *sessionStore) RotateRefresh(sessionID string, oldJti string, newJti string) (bool, error) {
+	// 以旧 jti 作为条件更新，并发刷新时只有一个请求能成功
+	result := DB.Model(&model.RefreshToken{}).
+		Where("session_hash = ? AND jti = ? AND expire_time >= ?", session.HashToken(sessionID), oldJti, time.Now()).
+		Update("jti", newJti)
+	return result.RowsAffected == 1, result.Error
+}
+
+// addRevokedToken 写入黑名单并清理已过期的记录
+func (s *sessionStore) addRevokedToken(revoked *model.RevokedToken) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expire_time < ?", time.Now()).Delete(&model.RevokedToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(revoked).Error
+	})
+}
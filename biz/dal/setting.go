@@ -0,0 +1,40 @@
+package dal
+
+import (
+	"confkeeper/biz/model"
+	"errors"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// GetSetting 获取系统设置，未设置时返回空字符串
+func GetSetting(key string) (string, error) {
+	var setting model.Setting
+	if err := DB.Where("setting_key = ?", key).First(&setting).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return setting.Value, nil
+}
+
+// SetSetting 保存系统设置
+func SetSetting(key string, value string) error {
+	return DB.Save(&model.Setting{Key: key, Value: value}).Error
+}
+
+// IsTotpRequired 是否要求所有用户启用两步验证
+func IsTotpRequired() (bool, error) {
+	value, err := GetSetting(model.SettingTotpRequired)
+	if err != nil || value == "" {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
+
+// SetTotpRequired 设置是否要求所有用户启用两步验证
+func SetTotpRequired(required bool) error {
+	return SetSetting(model.SettingTotpRequired, strconv.FormatBool(required))
+}
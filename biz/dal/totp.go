@@ -0,0 +1,97 @@
+package dal
+
+import (
+	"confkeeper/biz/model"
+	"confkeeper/utils/totp"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SaveTotpSecret 保存待绑定的 TOTP 密钥，校验验证码后才会启用
+func SaveTotpSecret(user *model.User, secret string) error {
+	return DB.Model(user).Updates(map[string]interface{}{
+		"totp_secret":    secret,
+		"totp_enabled":   false,
+		"totp_last_step": 0,
+	}).Error
+}
+
+// EnableTotp 启用两步验证并生成新的恢复码，step 为绑定时使用的时间窗口
+func EnableTotp(user *model.User, step int64, recoveryCodes []string) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(user).Updates(map[string]interface{}{
+			"totp_enabled":   true,
+			"totp_last_step": step,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(tx, user.ID, recoveryCodes)
+	})
+}
+
+// DisableTotp 停用两步验证，同时删除密钥和恢复码
+func DisableTotp(user *model.User) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(user).Updates(map[string]interface{}{
+			"totp_secret":    "",
+			"totp_enabled":   false,
+			"totp_last_step": 0,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", user.ID).Delete(&model.UserRecoveryCode{}).Error
+	})
+}
+
+// ReplaceRecoveryCodes 重新生成恢复码，旧的恢复码全部失效
+func ReplaceRecoveryCodes(userID uint, recoveryCodes []string) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, recoveryCodes)
+	})
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, recoveryCodes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&model.UserRecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	records := make([]*model.UserRecoveryCode, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		records = append(records, &model.UserRecoveryCode{
+			UserID:     userID,
+			CodeHash:   totp.HashRecoveryCode(code),
+			CreateTime: time.Now(),
+		})
+	}
+	return tx.Create(&records).Error
+}
+
+// CountRecoveryCodes 统计用户剩余可用的恢复码数量
+func CountRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	err := DB.Model(&model.UserRecoveryCode{}).Where("user_id = ? AND used_time IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// VerifyTotpCode 校验已启用两步验证的用户提交的验证码或恢复码
+// 验证码和恢复码都只能使用一次，并发提交同一个验证码时只有一个请求能通过
+func VerifyTotpCode(user *model.User, code string) (bool, error) {
+	if !user.TotpEnabled {
+		return false, nil
+	}
+
+	if step, ok := totp.Validate(user.TotpSecret, code, time.Now()); ok {
+		result := DB.Model(&model.User{}).
+			Where("id = ? AND totp_last_step < ?", user.ID, step).
+			Update("totp_last_step", step)
+		return result.RowsAffected == 1, result.Error
+	}
+
+	result := DB.Model(&model.UserRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_time IS NULL", user.ID, totp.HashRecoveryCode(code)).
+		Update("used_time", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
@@ -2,10 +2,13 @@ package dal
 
 import (
 	"confkeeper/biz/model"
+	"confkeeper/utils/password"
 	"errors"
 	"fmt"
 
+	"github.com/gookit/slog"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func CreateUser(users []*model.User) error {
@@ -27,7 +30,17 @@ func DeleteUser(userId int) error {
 		return err
 	}
 
-	return DB.Delete(&user).Error
+	return DB.Transaction(func(tx *gorm.DB) error {
+		// 删除用户的 API Key
+		if err := tx.Where("user_id = ?", user.ID).Delete(&model.ApiKey{}).Error; err != nil {
+			return err
+		}
+		// 删除用户的两步验证恢复码
+		if err := tx.Where("user_id = ?", user.ID).Delete(&model.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&user).Error
+	})
 }
 
 // GetUserByID 根据用户 ID 获取用户信息
@@ -42,13 +55,41 @@ func GetUserByID(userId int) (*model.User, error) {
 	return &user, nil
 }
 
-// UpdateUser 更新用户信息
+// ErrLastAdmin 取消权限或禁用后将没有启用的管理员
+var ErrLastAdmin = errors.New("至少需要保留一个启用的管理员")
+
+// UpdateUser 更新用户，取消最后一个启用的管理员的权限或禁用该管理员时返回 ErrLastAdmin
 func UpdateUser(user *model.User) error {
-	return DB.Model(user).Updates(map[string]interface{}{
-		"username": user.Username,
-		"password": user.Password,
-		"enabled":  user.Enable,
-	}).Error
+	return DB.Transaction(func(tx *gorm.DB) error {
+		// 锁定所有启用的管理员，并发修改管理员时按顺序检查，sqlite 会忽略行锁
+		if !user.IsAdmin || !user.Enable {
+			var admins []*model.User
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("is_admin = ? AND enabled = ?", true, true).
+				Find(&admins).Error
+			if err != nil {
+				return err
+			}
+			if len(admins) == 1 && admins[0].ID == user.ID {
+				return ErrLastAdmin
+			}
+		}
+
+		return tx.Model(user).Updates(map[string]interface{}{
+			"username": user.Username,
+			"password": user.Password,
+			"enabled":  user.Enable,
+			"is_admin": user.IsAdmin,
+			"source":   user.Source,
+		}).Error
+	})
+}
+
+// IsAdminUser 判断用户是否为管理员
+func IsAdminUser(userId int) (bool, error) {
+	var count int64
+	err := DB.Model(&model.User{}).Where("id = ? AND is_admin = ?", userId, true).Count(&count).Error
+	return count > 0, err
 }
 
 // GetUserList 获取用户列表（分页）
@@ -75,6 +116,19 @@ func GetUserList(pageSize int, offset int, username string) ([]*model.User, int6
 	return users, total, nil
 }
 
+// GetUserByOidcIdentity 按绑定的 OIDC issuer 和 subject 查找用户，不存在时返回 nil
+func GetUserByOidcIdentity(issuer string, subject string) (*model.User, error) {
+	var user model.User
+	err := DB.Where("source = ? AND oidc_issuer = ? AND oidc_subject = ?", model.UserSourceOidc, issuer, subject).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
 func UserLogin(username string) (*model.User, error) {
 	var user model.User
 
@@ -87,3 +141,44 @@ func UserLogin(username string) (*model.User, error) {
 	}
 	return &user, nil
 }
+
+// SyncUserPassword 外部身份源认证通过后同步本地密码哈希，供 Nacos 等只校验本地密码的登录方式使用
+func SyncUserPassword(user *model.User, plain string) error {
+	if ok, needsRehash := password.Verify(user.Password, plain); ok && !needsRehash {
+		return nil
+	}
+	hashed, err := password.Hash(plain)
+	if err != nil {
+		return err
+	}
+	return DB.Model(user).Update("password", hashed).Error
+}
+
+// UpdateUserSource 修改用户来源
+func UpdateUserSource(user *model.User, source string) error {
+	if err := DB.Model(user).Update("source", source).Error; err != nil {
+		return err
+	}
+	user.Source = source
+	return nil
+}
+
+// VerifyUserPassword 校验用户密码，校验通过且密码哈希使用旧算法或旧参数时重新生成并保存
+func VerifyUserPassword(user *model.User, plain string) bool {
+	ok, needsRehash := password.Verify(user.Password, plain)
+	if !ok {
+		return false
+	}
+
+	if needsRehash {
+		hashed, err := password.Hash(plain)
+		if err != nil {
+			slog.Warnf("用户 %s 密码哈希升级失败: %v", user.Username, err)
+			return true
+		}
+		if err := DB.Model(user).Update("password", hashed).Error; err != nil {
+			slog.Warnf("用户 %s 密码哈希升级失败: %v", user.Username, err)
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package dal
+
+import (
+	"confkeeper/biz/model"
+	"errors"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func CreateWebhook(hook *model.Webhook) error {
+	return DB.Create(hook).Error
+}
+
+func UpdateWebhook(hook *model.Webhook) error {
+	hook.UpdateTime = time.Now()
+	return DB.Model(hook).
+		Select("name", "kind", "url", "secret", "group_filter", "data_id_filter", "events", "enabled", "author", "update_time").
+		Updates(hook).Error
+}
+
+// DeleteWebhook 删除 Webhook 及其投递记录
+func DeleteWebhook(id uint) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.WebhookDelivery{}, "webhook_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Webhook{}, "id = ?", id).Error
+	})
+}
+
+func GetWebhookByID(id uint) (*model.Webhook, error) {
+	var hook model.Webhook
+	if err := DB.First(&hook, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// GetWebhookList scope 不为 nil 时只返回订阅范围都在 scope 内的 Webhook
+func GetWebhookList(pageSize, offset int, tenantId string, scope *ResourceScope) ([]*model.Webhook, int64, error) {
+	var hooks []*model.Webhook
+	query := DB.Model(&model.Webhook{}).Where("tenant_id = ?", tenantId)
+
+	if scope != nil {
+		// 过滤条件是通配符，无法在数据库中比较范围，命名空间下的 Webhook 不多，全部取出后过滤
+		if err := query.Order("id").Find(&hooks).Error; err != nil {
+			return nil, 0, err
+		}
+		hooks = slices.DeleteFunc(hooks, func(hook *model.Webhook) bool {
+			group, dataId := hook.FilterRange()
+			return !scope.Covers(hook.TenantID, group, dataId)
+		})
+		total := int64(len(hooks))
+		if offset >= len(hooks) {
+			return []*model.Webhook{}, total, nil
+		}
+		return hooks[offset:min(offset+pageSize, len(hooks))], total, nil
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id").Limit(pageSize).Offset(offset).Find(&hooks).Error
+	return hooks, total, err
+}
+
+// GetEnabledWebhooksByTenant 获取命名空间下启用的 Webhook
+func GetEnabledWebhooksByTenant(tenantId string) ([]*model.Webhook, error) {
+	var hooks []*model.Webhook
+	err := DB.Where("tenant_id = ? AND enabled = ?", tenantId, true).Order("id").Find(&hooks).Error
+	return hooks, err
+}
+
+func CreateWebhookDelivery(delivery *model.WebhookDelivery) error {
+	now := time.Now()
+	delivery.CreateTime = now
+	delivery.UpdateTime = now
+	return DB.Create(delivery).Error
+}
+
+// UpdateWebhookDelivery 更新每次尝试的结果
+func UpdateWebhookDelivery(delivery *model.WebhookDelivery) error {
+	delivery.UpdateTime = time.Now()
+	return DB.Model(delivery).
+		Select("success", "attempts", "status_code", "error", "response", "update_time").
+		Updates(delivery).Error
+}
+
+// GetWebhookDeliveryList 分页查询 Webhook 的投递记录，按时间倒序
+func GetWebhookDeliveryList(pageSize, offset int, webhookId uint) ([]*model.WebhookDelivery, int64, error) {
+	var deliveries []*model.WebhookDelivery
+	query := DB.Model(&model.WebhookDelivery{}).Where("webhook_id = ?", webhookId)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id DESC").Limit(pageSize).Offset(offset).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+// DeleteWebhookDeliveriesBefore 删除指定时间之前的投递记录，返回删除的条数
+func DeleteWebhookDeliveriesBefore(t time.Time) (int64, error) {
+	result := DB.Where("create_time < ?", t).Delete(&model.WebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
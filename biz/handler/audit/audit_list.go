@@ -0,0 +1,124 @@
+package audit
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListReq struct {
+	Page       int32  `form:"page" binding:"required,min=1,max=1000"`
+	PageSize   int32  `form:"page_size" binding:"required,min=1,max=100"`
+	Actor      string `form:"actor" binding:"omitempty,max=255"`
+	ClientIP   string `form:"client_ip" binding:"omitempty,max=64"`
+	TargetType string `form:"target_type" binding:"omitempty,max=32"`
+	Action     string `form:"action" binding:"omitempty,max=32"`
+	// 按操作对象模糊查询
+	Target    string    `form:"target" binding:"omitempty,max=512"`
+	StartTime time.Time `form:"start_time" time_format:"2006-01-02 15:04:05"`
+	EndTime   time.Time `form:"end_time" time_format:"2006-01-02 15:04:05"`
+}
+
+type ListData struct {
+	Id         uint   `json:"id"`
+	Actor      string `json:"actor"`
+	ClientIP   string `json:"client_ip"`
+	TargetType string `json:"target_type"`
+	Action     string `json:"action"`
+	Target     string `json:"target"`
+	// 操作前摘要
+	Before string `json:"before"`
+	// 操作后摘要
+	After      string `json:"after"`
+	CreateTime string `json:"create_time"`
+}
+
+type ListResp struct {
+	Code  response.Code `json:"code"`
+	Msg   string        `json:"msg"`
+	Total int64         `json:"total"`
+	Data  []*ListData   `json:"data"`
+}
+
+// AuditList 审计日志列表
+//
+//	@Tags			审计日志
+//	@Summary		审计日志列表
+//	@Description	按操作人、IP、操作对象、操作和时间范围分页查询审计日志，按时间倒序
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			page		query		int		false	"页码"	default(1)
+//	@Param			page_size	query		int		false	"每页数量"	default(10)
+//	@Param			actor		query		string	false	"操作人"
+//	@Param			client_ip	query		string	false	"客户端IP"
+//	@Param			target_type	query		string	false	"操作对象类型: config / config_beta / schema / tenant / user / role / permission / service_account / api_key / webhook / setting"
+//	@Param			action		query		string	false	"操作"
+//	@Param			target		query		string	false	"操作对象，模糊查询"
+//	@Param			start_time	query		string	false	"开始时间，格式 2006-01-02 15:04:05"
+//	@Param			end_time	query		string	false	"结束时间，格式 2006-01-02 15:04:05"
+//	@Success		200			{object}	ListResp
+//	@Security		ApiKeyAuth
+//	@router			/api/audit/list [GET]
+func AuditList(c *gin.Context) {
+	req := new(ListReq)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(ListResp)
+
+	// 检查管理员权限
+	if err := mw.IsAdmin(c); err != nil {
+		c.JSON(http.StatusOK, &ListResp{
+			Code: response.Code_Unauthorized,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	filter := &dal.AuditLogFilter{
+		Actor:      req.Actor,
+		ClientIP:   req.ClientIP,
+		TargetType: req.TargetType,
+		Action:     req.Action,
+		Target:     req.Target,
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+	}
+	offset := (req.Page - 1) * req.PageSize
+
+	logs, total, err := dal.GetAuditLogList(filter, int(req.PageSize), int(offset))
+	if err != nil {
+		c.JSON(http.StatusOK, &ListResp{
+			Code: response.Code_DBErr,
+			Msg:  "获取审计日志失败: " + err.Error(),
+		})
+		return
+	}
+
+	list := make([]*ListData, 0, len(logs))
+	for _, log := range logs {
+		list = append(list, &ListData{
+			Id:         log.ID,
+			Actor:      log.Actor,
+			ClientIP:   log.ClientIP,
+			TargetType: log.TargetType,
+			Action:     log.Action,
+			Target:     log.Target,
+			Before:     log.Before,
+			After:      log.After,
+			CreateTime: log.CreateTime.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "获取成功"
+	resp.Total = total
+	resp.Data = list
+
+	c.JSON(http.StatusOK, resp)
+}
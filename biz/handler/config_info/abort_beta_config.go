@@ -0,0 +1,67 @@
+package config_info
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/handler"
+	"confkeeper/biz/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AbortBetaConfig 终止灰度
+//
+//	@Tags			配置
+//	@Summary		终止灰度
+//	@Description	删除灰度内容，灰度客户端回到正式版本
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			config_id	path		string	true	"配置ID"
+//	@Success		200			{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/config/beta/abort/{config_id} [POST]
+func AbortBetaConfig(c *gin.Context) {
+	uriReq := new(BetaUriReq)
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	configInfoData, ok := getBetaTargetConfig(c, uriReq.ConfigId, "没有终止灰度的权限")
+	if !ok {
+		return
+	}
+
+	beta, err := dal.GetConfigInfoBeta(configInfoData.DataID, configInfoData.GroupID, configInfoData.TenantID)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if beta == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "该配置没有灰度版本",
+		})
+		return
+	}
+
+	if err = dal.DeleteConfigInfoBeta(beta); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "终止灰度失败: " + err.Error(),
+		})
+		return
+	}
+
+	auditConfigBeta(c, "abort", beta, nil)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "终止灰度成功"
+
+	c.JSON(http.StatusOK, resp)
+	handler.IncConfigChange()
+}
@@ -0,0 +1,108 @@
+package config_info
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BetaContentData struct {
+	DataId     string   `json:"data_id"`
+	GroupId    string   `json:"group_id"`
+	TenantId   string   `json:"tenant_id"`
+	Content    string   `json:"content"`
+	Type       string   `json:"type"`
+	Md5        string   `json:"md5"`
+	Sha256     string   `json:"sha256"`
+	BetaIps    []string `json:"beta_ips"`
+	BetaLabels []string `json:"beta_labels"`
+	Author     string   `json:"author"`
+	UpdateTime string   `json:"update_time"`
+}
+
+type BetaContentResp struct {
+	Code response.Code    `json:"code"`
+	Msg  string           `json:"msg"`
+	Data *BetaContentData `json:"data"`
+}
+
+// BetaConfigContent 获取灰度配置
+//
+//	@Tags			配置
+//	@Summary		获取灰度配置
+//	@Description	获取配置当前的灰度内容及规则，没有灰度时 data 为空
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			config_id	path		string	true	"配置ID"
+//	@Success		200			{object}	BetaContentResp
+//	@Security		ApiKeyAuth
+//	@router			/api/config/beta/get/{config_id} [GET]
+func BetaConfigContent(c *gin.Context) {
+	uriReq := new(BetaUriReq)
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(BetaContentResp)
+
+	configInfoData, err := dal.GetConfigInfoByID(uriReq.ConfigId)
+	if err != nil {
+		c.JSON(http.StatusOK, &BetaContentResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if configInfoData == nil {
+		c.JSON(http.StatusOK, &BetaContentResp{
+			Code: response.Code_Err,
+			Msg:  "配置不存在",
+		})
+		return
+	}
+
+	// 权限检查：管理员或有该配置r/rw权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的r或rw权限
+		hasPermission, err := mw.CheckConfigReadPermissionHTTP(c, configInfoData.TenantID, configInfoData.GroupID, configInfoData.DataID)
+		if err != nil || !hasPermission {
+			c.JSON(http.StatusOK, &BetaContentResp{
+				Code: response.Code_Unauthorized,
+				Msg:  "没有查看配置的权限",
+			})
+			return
+		}
+	}
+
+	beta, err := dal.GetConfigInfoBeta(configInfoData.DataID, configInfoData.GroupID, configInfoData.TenantID)
+	if err != nil {
+		c.JSON(http.StatusOK, &BetaContentResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "获取成功"
+	if beta != nil {
+		resp.Data = &BetaContentData{
+			DataId:     beta.DataID,
+			GroupId:    beta.GroupID,
+			TenantId:   beta.TenantID,
+			Content:    beta.Content,
+			Type:       beta.Type,
+			Md5:        beta.Md5,
+			Sha256:     beta.Sha256,
+			BetaIps:    splitBetaRule(beta.BetaIps),
+			BetaLabels: splitBetaRule(beta.BetaLabels),
+			Author:     beta.Author,
+			UpdateTime: beta.UpdateTime.Format("2006-01-02 15:04:05"),
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
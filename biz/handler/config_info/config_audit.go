@@ -0,0 +1,73 @@
+package config_info
+
+import (
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// configAuditSummary 配置的审计摘要，只记录版本和md5，不记录配置内容
+type configAuditSummary struct {
+	TenantId string `json:"tenant_id"`
+	DataId   string `json:"data_id"`
+	GroupId  string `json:"group_id"`
+	Version  int    `json:"version"`
+	Type     string `json:"type"`
+	Md5      string `json:"md5"`
+}
+
+// auditConfig 记录配置的变更，before/after 为空表示新建/删除，克隆时 before 为原配置
+func auditConfig(c *gin.Context, targetType string, action string, before *model.ConfigInfo, after *model.ConfigInfo) {
+	target := after
+	if target == nil {
+		target = before
+	}
+	resource := utils.Resource{Tenant: target.TenantID, Group: target.GroupID, DataId: target.DataID}
+	mw.Audit(c, targetType, action, resource.String(), newConfigAuditSummary(before), newConfigAuditSummary(after))
+}
+
+func newConfigAuditSummary(info *model.ConfigInfo) interface{} {
+	if info == nil {
+		return nil
+	}
+	return &configAuditSummary{
+		TenantId: info.TenantID,
+		DataId:   info.DataID,
+		GroupId:  info.GroupID,
+		Version:  info.Version,
+		Type:     info.Type,
+		Md5:      info.Md5,
+	}
+}
+
+// configBetaAuditSummary 灰度配置的审计摘要
+type configBetaAuditSummary struct {
+	Type       string `json:"type"`
+	Md5        string `json:"md5"`
+	BetaIps    string `json:"beta_ips"`
+	BetaLabels string `json:"beta_labels"`
+}
+
+// auditConfigBeta 记录灰度配置的变更，before/after 为空表示发布/终止
+func auditConfigBeta(c *gin.Context, action string, before *model.ConfigInfoBeta, after *model.ConfigInfoBeta) {
+	target := after
+	if target == nil {
+		target = before
+	}
+	resource := utils.Resource{Tenant: target.TenantID, Group: target.GroupID, DataId: target.DataID}
+	mw.Audit(c, model.AuditTargetConfigBeta, action, resource.String(), newConfigBetaAuditSummary(before), newConfigBetaAuditSummary(after))
+}
+
+func newConfigBetaAuditSummary(beta *model.ConfigInfoBeta) interface{} {
+	if beta == nil {
+		return nil
+	}
+	return &configBetaAuditSummary{
+		Type:       beta.Type,
+		Md5:        beta.Md5,
+		BetaIps:    beta.BetaIps,
+		BetaLabels: beta.BetaLabels,
+	}
+}
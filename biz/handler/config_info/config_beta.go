@@ -0,0 +1,114 @@
+package config_info
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// configLabelsHeader 客户端通过该请求头上报自身标签，格式为逗号分隔的 key=value
+const configLabelsHeader = "X-Config-Labels"
+
+// clientConfig 请求方应获取的配置内容
+type clientConfig struct {
+	Content string
+	Md5     string
+	IsBeta  bool
+}
+
+// resolveClientConfig 请求方命中灰度规则时返回灰度内容，否则返回正式版本
+func resolveClientConfig(c *gin.Context, released *model.ConfigInfo) (*clientConfig, error) {
+	beta, err := dal.GetConfigInfoBeta(released.DataID, released.GroupID, released.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if beta != nil && matchBeta(c, beta) {
+		return &clientConfig{Content: beta.Content, Md5: beta.Md5, IsBeta: true}, nil
+	}
+	return &clientConfig{Content: released.Content, Md5: released.Md5}, nil
+}
+
+// matchBeta 判断请求方的IP或标签是否命中灰度规则
+// 客户端IP只在来源是 server.trusted_proxies 中的代理时才取自 X-Forwarded-For，客户端无法伪造IP命中灰度
+func matchBeta(c *gin.Context, beta *model.ConfigInfoBeta) bool {
+	if clientIP, err := netip.ParseAddr(c.ClientIP()); err == nil {
+		clientIP = clientIP.Unmap()
+		for _, rule := range splitBetaRule(beta.BetaIps) {
+			if strings.Contains(rule, "/") {
+				if prefix, err := netip.ParsePrefix(rule); err == nil && prefix.Contains(clientIP) {
+					return true
+				}
+			} else if ip, err := netip.ParseAddr(rule); err == nil && ip.Unmap() == clientIP {
+				return true
+			}
+		}
+	}
+
+	betaLabels := splitBetaRule(beta.BetaLabels)
+	for _, label := range splitBetaRule(c.GetHeader(configLabelsHeader)) {
+		if slices.Contains(betaLabels, normalizeBetaLabel(label)) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeBetaRules 校验并规范化灰度IP和标签规则
+func normalizeBetaRules(ips []string, labels []string) (string, string, error) {
+	normalizedIps := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		ip = strings.TrimSpace(ip)
+		if strings.Contains(ip, "/") {
+			prefix, err := netip.ParsePrefix(ip)
+			if err != nil {
+				return "", "", fmt.Errorf("灰度IP格式错误: %s", ip)
+			}
+			ip = prefix.Masked().String()
+		} else {
+			addr, err := netip.ParseAddr(ip)
+			if err != nil {
+				return "", "", fmt.Errorf("灰度IP格式错误: %s", ip)
+			}
+			ip = addr.Unmap().String()
+		}
+		if !slices.Contains(normalizedIps, ip) {
+			normalizedIps = append(normalizedIps, ip)
+		}
+	}
+
+	normalizedLabels := make([]string, 0, len(labels))
+	for _, label := range labels {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || strings.TrimSpace(key) == "" || strings.ContainsAny(label, ",") {
+			return "", "", fmt.Errorf("灰度标签格式错误，应为key=value: %s", label)
+		}
+		label = normalizeBetaLabel(key + "=" + value)
+		if !slices.Contains(normalizedLabels, label) {
+			normalizedLabels = append(normalizedLabels, label)
+		}
+	}
+
+	return strings.Join(normalizedIps, ","), strings.Join(normalizedLabels, ","), nil
+}
+
+// normalizeBetaLabel 去除标签 key 和 value 两侧的空白
+func normalizeBetaLabel(label string) string {
+	key, value, _ := strings.Cut(label, "=")
+	return strings.TrimSpace(key) + "=" + strings.TrimSpace(value)
+}
+
+// splitBetaRule 拆分逗号分隔的规则，忽略空项
+func splitBetaRule(rule string) []string {
+	var items []string
+	for _, item := range strings.Split(rule, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
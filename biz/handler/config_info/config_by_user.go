@@ -0,0 +1,58 @@
+package config_info
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authenticateByUser 校验按账号读写配置的请求的用户名和密码，启用了两步验证的用户还需要提交验证码
+// 校验通过后把用户保存到上下文，密码和验证码错误都计入登录失败锁定
+func authenticateByUser(c *gin.Context, username string, password string, totpCode string) error {
+	if err := dal.CheckLoginLimit(username, c.ClientIP()); err != nil {
+		return err
+	}
+
+	userData, err := dal.UserLogin(username)
+	if err != nil {
+		dal.RecordLoginFailure(username, c.ClientIP())
+		return err
+	}
+	if err := mw.VerifyPassword(c, userData, password); err != nil {
+		if !errors.Is(err, mw.ErrUserSync) {
+			dal.RecordLoginFailure(username, c.ClientIP())
+		}
+		return err
+	}
+	if !userData.Enable {
+		return errors.New("用户已禁用")
+	}
+
+	totpRequired, err := dal.IsTotpRequired()
+	if err != nil {
+		return errors.New("数据库查询错误")
+	}
+	switch {
+	case userData.TotpEnabled:
+		if totpCode == "" {
+			return errors.New("用户已启用两步验证，请提供 totp_code")
+		}
+		ok, err := dal.VerifyTotpCode(userData, totpCode)
+		if err != nil {
+			return errors.New("数据库查询错误")
+		}
+		if !ok {
+			dal.RecordLoginFailure(username, c.ClientIP())
+			return errors.New("两步验证码错误")
+		}
+	case totpRequired:
+		return errors.New("管理员要求启用两步验证，请先登录控制台完成绑定")
+	}
+
+	dal.RecordLoginSuccess(userData)
+	c.Set("userid", int(userData.ID))
+	c.Set("username", userData.Username)
+	return nil
+}
@@ -5,7 +5,6 @@ import (
 	"confkeeper/biz/handler"
 	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -22,6 +21,8 @@ type ContentData struct {
 	DataId   string `json:"data_id"`
 	GroupId  string `json:"group_id"`
 	TenantId string `json:"tenant_id"`
+	Md5      string `json:"md5"`
+	Sha256   string `json:"sha256"`
 }
 
 type ContentResp struct {
@@ -67,10 +68,10 @@ func ConfigContent(c *gin.Context) {
 		return
 	}
 
-	// 权限检查：管理员或有命名空间r/rw权限的用户
-	if err := utils.IsAdmin(c); err != nil {
-		// 检查用户是否有命名空间的r或rw权限
-		hasPermission, err := mw.CheckNamespaceReadOrWritePermissionHTTP(c, configInfoData.TenantID)
+	// 权限检查：管理员或有该配置r/rw权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的r或rw权限
+		hasPermission, err := mw.CheckConfigReadPermissionHTTP(c, configInfoData.TenantID, configInfoData.GroupID, configInfoData.DataID)
 		if err != nil || !hasPermission {
 			c.JSON(http.StatusOK, &ContentResp{
 				Code: response.Code_Unauthorized,
@@ -89,6 +90,8 @@ func ConfigContent(c *gin.Context) {
 		DataId:   configInfoData.DataID,
 		GroupId:  configInfoData.GroupID,
 		TenantId: configInfoData.TenantID,
+		Md5:      configInfoData.Md5,
+		Sha256:   configInfoData.Sha256,
 	}
 
 	c.JSON(http.StatusOK, resp)
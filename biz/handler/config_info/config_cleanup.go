@@ -2,8 +2,9 @@ package config_info
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -23,7 +24,7 @@ func ConfigCleanup(c *gin.Context) {
 	resp := new(response.CommonResp)
 
 	// 权限检查：仅管理员可执行清理操作
-	if err := utils.IsAdmin(c); err != nil {
+	if err := mw.IsAdmin(c); err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_Unauthorized,
 			Msg:  "只有管理员可以执行清理操作",
@@ -32,7 +33,8 @@ func ConfigCleanup(c *gin.Context) {
 	}
 
 	// 执行清理操作
-	if err := dal.ClearOldConfigVersions(); err != nil {
+	deleted, err := dal.ClearOldConfigVersions()
+	if err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_DBErr,
 			Msg:  "清理配置版本失败: " + err.Error(),
@@ -40,6 +42,8 @@ func ConfigCleanup(c *gin.Context) {
 		return
 	}
 
+	mw.Audit(c, model.AuditTargetConfig, "cleanup", "*:*:*", nil, map[string]int64{"deleted": deleted})
+
 	resp.Code = response.Code_Success
 	resp.Msg = "配置版本清理成功"
 
@@ -6,7 +6,6 @@ import (
 	"confkeeper/biz/model"
 	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"fmt"
 	"net/http"
 
@@ -22,6 +21,8 @@ type CloneItems struct {
 type CloneReq struct {
 	TenantId string        `json:"tenant_id"`
 	Items    []*CloneItems `json:"items"`
+	// 跳过内容格式及Schema校验，仅管理员有效
+	Force bool `json:"force"`
 }
 
 // ConfigClone 克隆配置
@@ -47,8 +48,9 @@ func ConfigClone(c *gin.Context) {
 	}
 	resp := new(response.CommonResp)
 
-	// 权限检查：管理员或有命名空间rw权限的用户
-	if err := utils.IsAdmin(c); err != nil {
+	// 权限检查：管理员或有命名空间rw权限的用户，每个配置的权限在下面逐个检查
+	isAdmin := mw.IsAdmin(c) == nil
+	if !isAdmin {
 		// 检查用户是否有命名空间的rw权限
 		hasPermission, err := mw.CheckNamespaceWritePermissionHTTP(c, req.TenantId)
 		if err != nil || !hasPermission {
@@ -79,6 +81,7 @@ func ConfigClone(c *gin.Context) {
 
 	// 处理items
 	var configsToCreate []*model.ConfigInfo
+	var originalConfigs []*model.ConfigInfo
 
 	for _, item := range req.Items {
 		// 用config_id查询原配置
@@ -98,6 +101,14 @@ func ConfigClone(c *gin.Context) {
 			return
 		}
 
+		if !isAdmin && !canCloneConfig(c, originalConfig, req.TenantId, item) {
+			c.JSON(http.StatusOK, &response.CommonResp{
+				Code: response.Code_Unauthorized,
+				Msg:  fmt.Sprintf("没有克隆配置的权限: group_id=%s, data_id=%s", item.GroupId, item.DataId),
+			})
+			return
+		}
+
 		// 创建新配置，version设为1
 		newConfig := &model.ConfigInfo{
 			DataID:   item.DataId,
@@ -108,7 +119,13 @@ func ConfigClone(c *gin.Context) {
 			Version:  1,
 			Author:   c.GetString("username"),
 		}
+
+		// 目标配置可能绑定了不同的Schema，克隆前同样校验
+		if !validateConfigContent(c, newConfig, req.Force) {
+			return
+		}
 		configsToCreate = append(configsToCreate, newConfig)
+		originalConfigs = append(originalConfigs, originalConfig)
 	}
 
 	// 批量创建新配置
@@ -122,9 +139,23 @@ func ConfigClone(c *gin.Context) {
 		}
 	}
 
+	for i, newConfig := range configsToCreate {
+		auditConfig(c, model.AuditTargetConfig, "clone", originalConfigs[i], newConfig)
+	}
+
 	resp.Code = response.Code_Success
 	resp.Msg = "配置克隆成功"
 
 	c.JSON(http.StatusOK, resp)
 	handler.IncConfigChange()
 }
+
+// canCloneConfig 需要有原配置的读取权限和新配置的写权限
+func canCloneConfig(c *gin.Context, original *model.ConfigInfo, tenantId string, item *CloneItems) bool {
+	canRead, err := mw.CheckConfigReadPermissionHTTP(c, original.TenantID, original.GroupID, original.DataID)
+	if err != nil || !canRead {
+		return false
+	}
+	canWrite, err := mw.CheckConfigWritePermissionHTTP(c, tenantId, item.GroupId, item.DataId)
+	return err == nil && canWrite
+}
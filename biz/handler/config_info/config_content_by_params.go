@@ -5,7 +5,6 @@ import (
 	"confkeeper/biz/handler"
 	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 	"strconv"
 
@@ -57,10 +56,10 @@ func ConfigContentByParams(c *gin.Context) {
 	}
 	resp := new(ContentByParamsResp)
 
-	// 权限检查：管理员或有命名空间r/rw权限的用户
-	if err := utils.IsAdmin(c); err != nil {
-		// 检查用户是否有命名空间的r或rw权限
-		hasPermission, err := mw.CheckNamespaceReadOrWritePermissionHTTP(c, req.TenantId)
+	// 权限检查：管理员或有该配置r/rw权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的r或rw权限
+		hasPermission, err := mw.CheckConfigReadPermissionHTTP(c, req.TenantId, req.GroupId, req.DataId)
 		if err != nil || !hasPermission {
 			c.JSON(http.StatusOK, &ContentByParamsResp{
 				Code: response.Code_Unauthorized,
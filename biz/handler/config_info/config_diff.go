@@ -0,0 +1,193 @@
+package config_info
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils/config_parser"
+	"confkeeper/utils/diff"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DiffReq struct {
+	FromVersion int `form:"from_version" binding:"required,min=1"`
+	ToVersion   int `form:"to_version" binding:"omitempty,min=1"`
+}
+
+type DiffUriReq struct {
+	ConfigId string `uri:"config_id" binding:"required"`
+}
+
+type DiffKeyChange struct {
+	Key  string `json:"key"`
+	From any    `json:"from,omitempty"`
+	To   any    `json:"to,omitempty"`
+}
+
+type DiffKeyData struct {
+	Added   []*DiffKeyChange `json:"added"`
+	Removed []*DiffKeyChange `json:"removed"`
+	Changed []*DiffKeyChange `json:"changed"`
+}
+
+type DiffData struct {
+	DataId      string       `json:"data_id"`
+	GroupId     string       `json:"group_id"`
+	TenantId    string       `json:"tenant_id"`
+	Type        string       `json:"type"`
+	FromVersion int          `json:"from_version"`
+	ToVersion   int          `json:"to_version"`
+	Unified     string       `json:"unified"`
+	Keys        *DiffKeyData `json:"keys"`
+	KeysErr     string       `json:"keys_err"`
+}
+
+type DiffResp struct {
+	Code response.Code `json:"code"`
+	Msg  string        `json:"msg"`
+	Data *DiffData     `json:"data"`
+}
+
+// ConfigDiff 比较配置的两个版本
+//
+//	@Tags			配置
+//	@Summary		比较配置的两个版本
+//	@Description	返回两个版本之间的统一格式差异，json、yaml、toml、properties类型额外返回键级别差异(新增、删除、修改)
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			config_id		path		string	true	"配置ID"
+//	@Param			from_version	query		int		true	"旧版本"
+//	@Param			to_version		query		int		false	"新版本，默认为最新版本"
+//	@Success		200				{object}	DiffResp
+//	@Security		ApiKeyAuth
+//	@router			/api/config/diff/{config_id} [GET]
+func ConfigDiff(c *gin.Context) {
+	req := new(DiffReq)
+	uriReq := new(DiffUriReq)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(DiffResp)
+
+	// 获取配置信息以检查权限
+	configInfoData, err := dal.GetConfigInfoByID(uriReq.ConfigId)
+	if err != nil {
+		c.JSON(http.StatusOK, &DiffResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if configInfoData == nil {
+		c.JSON(http.StatusOK, &DiffResp{
+			Code: response.Code_Err,
+			Msg:  "配置不存在",
+		})
+		return
+	}
+
+	// 权限检查：管理员或有该配置r/rw权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的r或rw权限
+		hasPermission, err := mw.CheckConfigReadPermissionHTTP(c, configInfoData.TenantID, configInfoData.GroupID, configInfoData.DataID)
+		if err != nil || !hasPermission {
+			c.JSON(http.StatusOK, &DiffResp{
+				Code: response.Code_Unauthorized,
+				Msg:  "没有查看配置的权限",
+			})
+			return
+		}
+	}
+
+	// 未指定新版本时与最新版本比较
+	if req.ToVersion == 0 {
+		maxVersion, err := dal.GetMaxVersionByDataIdGroupAndTenant(configInfoData.DataID, configInfoData.GroupID, configInfoData.TenantID)
+		if err != nil {
+			c.JSON(http.StatusOK, &DiffResp{
+				Code: response.Code_DBErr,
+				Msg:  "数据库查询错误: " + err.Error(),
+			})
+			return
+		}
+		req.ToVersion = maxVersion
+	}
+
+	fromConfig, err := dal.GetConfigInfoByVersion(configInfoData.DataID, configInfoData.GroupID, configInfoData.TenantID, req.FromVersion)
+	if err != nil {
+		c.JSON(http.StatusOK, &DiffResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	toConfig, err := dal.GetConfigInfoByVersion(configInfoData.DataID, configInfoData.GroupID, configInfoData.TenantID, req.ToVersion)
+	if err != nil {
+		c.JSON(http.StatusOK, &DiffResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if fromConfig == nil || toConfig == nil {
+		c.JSON(http.StatusOK, &DiffResp{
+			Code: response.Code_Err,
+			Msg:  "版本不存在",
+		})
+		return
+	}
+
+	data := &DiffData{
+		DataId:      toConfig.DataID,
+		GroupId:     toConfig.GroupID,
+		TenantId:    toConfig.TenantID,
+		Type:        toConfig.Type,
+		FromVersion: fromConfig.Version,
+		ToVersion:   toConfig.Version,
+		Unified: diff.Unified(
+			fmt.Sprintf("%s@%d", fromConfig.DataID, fromConfig.Version),
+			fmt.Sprintf("%s@%d", toConfig.DataID, toConfig.Version),
+			fromConfig.Content,
+			toConfig.Content,
+		),
+	}
+
+	// 两个版本类型一致且为结构化类型时，比较键级别差异
+	if fromConfig.Type == toConfig.Type && config_parser.IsStructured(toConfig.Type) {
+		keyDiff, err := diff.Keys(toConfig.Type, fromConfig.Content, toConfig.Content)
+		if err != nil {
+			data.KeysErr = err.Error()
+		} else {
+			data.Keys = &DiffKeyData{
+				Added:   toDiffKeyChanges(keyDiff.Added),
+				Removed: toDiffKeyChanges(keyDiff.Removed),
+				Changed: toDiffKeyChanges(keyDiff.Changed),
+			}
+		}
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "获取配置差异成功"
+	resp.Data = data
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func toDiffKeyChanges(changes []*diff.KeyChange) []*DiffKeyChange {
+	result := make([]*DiffKeyChange, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, &DiffKeyChange{
+			Key:  change.Key,
+			From: change.From,
+			To:   change.To,
+		})
+	}
+	return result
+}
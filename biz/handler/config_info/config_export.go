@@ -0,0 +1,96 @@
+package config_info
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/handler"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils/config_zip"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gookit/slog"
+)
+
+type ExportReq struct {
+	TenantId string `form:"tenant_id" binding:"required,min=1,max=100"`
+}
+
+// ConfigExport 导出命名空间配置
+//
+//	@Tags			配置
+//	@Summary		导出命名空间配置
+//	@Description	将命名空间下每个配置的最新版本导出为 nacos v2 格式的 ZIP(group/dataId 文件 + .metadata.yml)
+//	@Accept			application/json
+//	@Produce		application/zip
+//	@Param			tenant_id	query	string	true	"命名空间id"
+//	@Success		200			{file}	file	"配置压缩包"
+//	@Security		ApiKeyAuth
+//	@router			/api/config/export [GET]
+func ConfigExport(c *gin.Context) {
+	req := new(ExportReq)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// 权限检查：管理员或有命名空间r/rw权限的用户，只返回用户有权限的配置
+	scope, err := mw.NamespaceReadScopeHTTP(c, req.TenantId)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Unauthorized,
+			Msg:  "没有导出配置的权限",
+		})
+		return
+	}
+
+	// 检查命名空间是否存在
+	exist, err := dal.IsTenantIdExists(req.TenantId)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "检查命名空间失败: " + err.Error(),
+		})
+		return
+	}
+	if !exist {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "命名空间不存在",
+		})
+		return
+	}
+
+	configInfos, err := dal.GetLatestConfigInfosByTenant(req.TenantId, scope)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "获取配置失败: " + err.Error(),
+		})
+		return
+	}
+
+	items := make([]*config_zip.Item, 0, len(configInfos))
+	for _, ci := range configInfos {
+		items = append(items, &config_zip.Item{
+			DataId:  ci.DataID,
+			Group:   ci.GroupID,
+			Type:    ci.Type,
+			Content: ci.Content,
+		})
+	}
+
+	fileName := fmt.Sprintf("confkeeper_config_export_%s_%s.zip", req.TenantId, time.Now().Format("20060102150405"))
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Status(http.StatusOK)
+
+	// 已开始写入响应体，出错时只能记录日志
+	if err := config_zip.Write(c.Writer, items); err != nil {
+		slog.Errorf("导出配置失败: %v", err)
+		return
+	}
+	handler.IncConfigRead()
+}
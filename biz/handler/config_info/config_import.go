@@ -0,0 +1,265 @@
+package config_info
+
+import (
+	"archive/zip"
+	"bytes"
+	"confkeeper/biz/dal"
+	"confkeeper/biz/handler"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils/config"
+	"confkeeper/utils/config_zip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// 冲突策略：存在同名配置时终止导入 / 跳过 / 覆盖(发布为新版本)
+	importPolicyAbort     = "ABORT"
+	importPolicySkip      = "SKIP"
+	importPolicyOverwrite = "OVERWRITE"
+
+	// 上传压缩包的最大大小
+	maxImportFileSize = 20 << 20
+)
+
+type ImportReq struct {
+	TenantId string `form:"tenant_id" binding:"required,min=1,max=100"`
+	Policy   string `form:"policy" binding:"omitempty,oneof=ABORT SKIP OVERWRITE"`
+	// 跳过内容格式及Schema校验，仅管理员有效
+	Force bool `form:"force"`
+}
+
+type ImportItem struct {
+	DataId string `json:"data_id"`
+	Group  string `json:"group"`
+}
+
+type ImportData struct {
+	SuccCount         int                        `json:"succ_count"`
+	SkipCount         int                        `json:"skip_count"`
+	FailData          []*ImportItem              `json:"fail_data"`
+	UnrecognizedCount int                        `json:"unrecognized_count"`
+	UnrecognizedData  []*config_zip.Unrecognized `json:"unrecognized_data"`
+}
+
+type ImportResp struct {
+	Code response.Code `json:"code"`
+	Msg  string        `json:"msg"`
+	Data *ImportData   `json:"data"`
+}
+
+// ConfigImport 导入命名空间配置
+//
+//	@Tags			配置
+//	@Summary		导入命名空间配置
+//	@Description	导入 nacos v1/v2 格式的配置压缩包。冲突策略：ABORT(默认，存在冲突时不导入任何配置)、SKIP(跳过已存在的配置)、OVERWRITE(已存在的配置发布为新版本)
+//	@Accept			multipart/form-data
+//	@Produce		application/json
+//	@Param			tenant_id	formData	string	true	"命名空间id"
+//	@Param			policy		formData	string	false	"冲突策略"	Enums(ABORT, SKIP, OVERWRITE)	default(ABORT)
+//	@Param			force		formData	bool	false	"跳过内容格式及Schema校验(仅管理员)"
+//	@Param			file		formData	file	true	"配置压缩包"
+//	@Success		200			{object}	ImportResp
+//	@Security		ApiKeyAuth
+//	@router			/api/config/import [POST]
+func ConfigImport(c *gin.Context) {
+	req := new(ImportReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Policy == "" {
+		req.Policy = importPolicyAbort
+	}
+	resp := new(ImportResp)
+
+	// 权限检查：管理员或有命名空间rw权限的用户，每个配置的权限在下面逐个检查
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有命名空间的rw权限
+		hasPermission, err := mw.CheckNamespaceWritePermissionHTTP(c, req.TenantId)
+		if err != nil || !hasPermission {
+			c.JSON(http.StatusOK, &ImportResp{
+				Code: response.Code_Unauthorized,
+				Msg:  "没有导入配置的权限",
+			})
+			return
+		}
+	}
+
+	// 检查命名空间是否存在
+	exist, err := dal.IsTenantIdExists(req.TenantId)
+	if err != nil {
+		c.JSON(http.StatusOK, &ImportResp{
+			Code: response.Code_DBErr,
+			Msg:  "检查命名空间失败: " + err.Error(),
+		})
+		return
+	}
+	if !exist {
+		c.JSON(http.StatusOK, &ImportResp{
+			Code: response.Code_Err,
+			Msg:  "命名空间不存在",
+		})
+		return
+	}
+
+	items, unrecognized, err := readImportFile(c)
+	if err != nil {
+		c.JSON(http.StatusOK, &ImportResp{
+			Code: response.Code_Err,
+			Msg:  "解析压缩包失败: " + err.Error(),
+		})
+		return
+	}
+
+	data := &ImportData{
+		UnrecognizedCount: len(unrecognized),
+		UnrecognizedData:  unrecognized,
+	}
+
+	var configsToCreate, configsToOverwrite []*model.ConfigInfo
+	isAdmin := mw.IsAdmin(c) == nil
+	skipValidate := req.Force && isAdmin
+	for _, item := range items {
+		cfg := &model.ConfigInfo{
+			DataID:   item.DataId,
+			GroupID:  item.Group,
+			Content:  item.Content,
+			TenantID: req.TenantId,
+			Type:     item.Type,
+			Version:  1,
+			Author:   c.GetString("username"),
+		}
+		// 不支持的类型按纯文本导入
+		if !slices.Contains(config.Cfg.Confkeeper.ConfigType, cfg.Type) {
+			cfg.Type = "text"
+		}
+
+		// 没有写权限或校验失败时终止整个导入，避免导入部分配置
+		if !isAdmin {
+			hasPermission, err := mw.CheckConfigWritePermissionHTTP(c, cfg.TenantID, cfg.GroupID, cfg.DataID)
+			if err != nil || !hasPermission {
+				c.JSON(http.StatusOK, &ImportResp{
+					Code: response.Code_Unauthorized,
+					Msg:  "没有导入配置的权限: " + item.Name(),
+				})
+				return
+			}
+		}
+		if !skipValidate {
+			if validateResp := checkConfigContent(cfg); validateResp != nil {
+				validateResp.Msg = fmt.Sprintf("%s: %s", item.Name(), validateResp.Msg)
+				c.JSON(http.StatusOK, validateResp)
+				return
+			}
+		}
+
+		exist, err := dal.IsConfigInfoExists(cfg.DataID, cfg.GroupID, cfg.TenantID)
+		if err != nil {
+			c.JSON(http.StatusOK, &ImportResp{
+				Code: response.Code_DBErr,
+				Msg:  "数据库查询错误: " + err.Error(),
+			})
+			return
+		}
+		if !exist {
+			configsToCreate = append(configsToCreate, cfg)
+			continue
+		}
+
+		switch req.Policy {
+		case importPolicyAbort:
+			data.FailData = append(data.FailData, &ImportItem{DataId: cfg.DataID, Group: cfg.GroupID})
+		case importPolicySkip:
+			data.SkipCount++
+		case importPolicyOverwrite:
+			configsToOverwrite = append(configsToOverwrite, cfg)
+		}
+	}
+
+	if len(data.FailData) > 0 {
+		c.JSON(http.StatusOK, &ImportResp{
+			Code: response.Code_AlreadyExists,
+			Msg:  fmt.Sprintf("存在%d个同名配置，已终止导入", len(data.FailData)),
+			Data: data,
+		})
+		return
+	}
+
+	// 新配置批量创建(version=1)
+	if len(configsToCreate) > 0 {
+		if err = dal.CreateConfigInfo(configsToCreate); err != nil {
+			c.JSON(http.StatusOK, &ImportResp{
+				Code: response.Code_DBErr,
+				Msg:  "创建配置失败: " + err.Error(),
+			})
+			return
+		}
+		data.SuccCount += len(configsToCreate)
+		for _, cfg := range configsToCreate {
+			auditConfig(c, model.AuditTargetConfig, "import", nil, cfg)
+		}
+	}
+
+	// 已存在的配置在最大版本+1上发布
+	for _, cfg := range configsToOverwrite {
+		err = dal.PublishConfigInfo(cfg, nil, "")
+		if errors.Is(err, dal.ErrVersionConflict) {
+			data.FailData = append(data.FailData, &ImportItem{DataId: cfg.DataID, Group: cfg.GroupID})
+			continue
+		}
+		if err != nil {
+			c.JSON(http.StatusOK, &ImportResp{
+				Code: response.Code_DBErr,
+				Msg:  "覆盖配置失败: " + err.Error(),
+				Data: data,
+			})
+			return
+		}
+		data.SuccCount++
+		auditConfig(c, model.AuditTargetConfig, "import", nil, cfg)
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "导入成功"
+	resp.Data = data
+
+	c.JSON(http.StatusOK, resp)
+	if data.SuccCount > 0 {
+		handler.IncConfigChange()
+	}
+}
+
+// readImportFile 读取上传的压缩包并解析配置
+func readImportFile(c *gin.Context) ([]*config_zip.Item, []*config_zip.Unrecognized, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, nil, err
+	}
+	if fileHeader.Size > maxImportFileSize {
+		return nil, nil, fmt.Errorf("压缩包超过 %dMB", maxImportFileSize>>20)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, nil, err
+	}
+	return config_zip.Read(zr)
+}
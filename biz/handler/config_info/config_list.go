@@ -4,7 +4,6 @@ import (
 	"confkeeper/biz/dal"
 	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 	"strconv"
 
@@ -25,6 +24,9 @@ type ListData struct {
 	DataId     string `json:"data_id"`
 	GroupId    string `json:"group_id"`
 	Type       string `json:"type"`
+	Md5        string `json:"md5"`
+	Sha256     string `json:"sha256"`
+	IsBeta     bool   `json:"is_beta"`
 	CreateTime string `json:"create_time"`
 }
 
@@ -59,17 +61,14 @@ func ConfigList(c *gin.Context) {
 	}
 	resp := new(ListResp)
 
-	// 权限检查：管理员或有命名空间r/rw权限的用户
-	if err := utils.IsAdmin(c); err != nil {
-		// 检查用户是否有命名空间的r或rw权限
-		hasPermission, err := mw.CheckNamespaceReadOrWritePermissionHTTP(c, req.TenantId)
-		if err != nil || !hasPermission {
-			c.JSON(http.StatusOK, &ListResp{
-				Code: response.Code_Unauthorized,
-				Msg:  "没有查看配置列表的权限",
-			})
-			return
-		}
+	// 权限检查：管理员或有命名空间r/rw权限的用户，只返回用户有权限的配置
+	scope, err := mw.NamespaceReadScopeHTTP(c, req.TenantId)
+	if err != nil {
+		c.JSON(http.StatusOK, &ListResp{
+			Code: response.Code_Unauthorized,
+			Msg:  "没有查看配置列表的权限",
+		})
+		return
 	}
 
 	if req.Page == 0 {
@@ -91,7 +90,7 @@ func ConfigList(c *gin.Context) {
 		Type = *req.Type
 	}
 
-	configInfos, total, err := dal.GetConfigInfoListWithMaxVersion(int(req.PageSize), int(offset), DataId, GroupId, Type, req.TenantId)
+	configInfos, total, err := dal.GetConfigInfoListWithMaxVersion(int(req.PageSize), int(offset), DataId, GroupId, Type, req.TenantId, scope)
 	if err != nil {
 		c.JSON(http.StatusOK, &ListResp{
 			Code: response.Code_DBErr,
@@ -100,6 +99,20 @@ func ConfigList(c *gin.Context) {
 		return
 	}
 
+	// 标记正在灰度中的配置
+	betas, err := dal.GetConfigInfoBetaListByTenant(req.TenantId)
+	if err != nil {
+		c.JSON(http.StatusOK, &ListResp{
+			Code: response.Code_DBErr,
+			Msg:  "获取灰度配置失败: " + err.Error(),
+		})
+		return
+	}
+	betaKeys := make(map[string]bool, len(betas))
+	for _, beta := range betas {
+		betaKeys[beta.DataID+"\x02"+beta.GroupID] = true
+	}
+
 	var configInfoList []*ListData
 	for _, b := range configInfos {
 		configInfoList = append(configInfoList, &ListData{
@@ -107,6 +120,9 @@ func ConfigList(c *gin.Context) {
 			DataId:     b.DataID,
 			GroupId:    b.GroupID,
 			Type:       b.Type,
+			Md5:        b.Md5,
+			Sha256:     b.Sha256,
+			IsBeta:     betaKeys[b.DataID+"\x02"+b.GroupID],
 			CreateTime: b.CreateTime.Format("2006-01-02 15:04:05"),
 		})
 	}
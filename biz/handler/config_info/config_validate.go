@@ -0,0 +1,71 @@
+package config_info
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils/config_schema"
+	"confkeeper/utils/config_validator"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ValidateResp struct {
+	Code response.Code              `json:"code"`
+	Msg  string                     `json:"msg"`
+	Data []*config_schema.Violation `json:"data"`
+}
+
+// validateConfigContent 发布前校验内容格式以及绑定的 JSON Schema，校验失败时写入响应并返回false
+// 管理员可通过 force 跳过校验，非管理员的 force 参数无效
+func validateConfigContent(c *gin.Context, cfg *model.ConfigInfo, force bool) bool {
+	if force && mw.IsAdmin(c) == nil {
+		return true
+	}
+
+	if resp := checkConfigContent(cfg); resp != nil {
+		c.JSON(http.StatusOK, resp)
+		return false
+	}
+	return true
+}
+
+// checkConfigContent 校验内容格式以及绑定的 JSON Schema，通过时返回 nil
+func checkConfigContent(cfg *model.ConfigInfo) *ValidateResp {
+	if err := config_validator.Validate(cfg.Type, cfg.Content); err != nil {
+		return &ValidateResp{
+			Code: response.Code_ValidateErr,
+			Msg:  "配置内容校验失败: " + err.Error(),
+		}
+	}
+
+	if !config_schema.IsSupported(cfg.Type) {
+		return nil
+	}
+	schema, err := dal.GetConfigSchema(cfg.DataID, cfg.GroupID, cfg.TenantID)
+	if err != nil {
+		return &ValidateResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		}
+	}
+	if schema == nil {
+		return nil
+	}
+
+	if err := config_schema.Validate(schema.Content, cfg.Type, cfg.Content); err != nil {
+		resp := &ValidateResp{
+			Code: response.Code_ValidateErr,
+			Msg:  "配置内容不符合Schema: " + err.Error(),
+		}
+		var schemaErr *config_schema.SchemaError
+		if errors.As(err, &schemaErr) {
+			resp.Data = schemaErr.Violations
+		}
+		return resp
+	}
+	return nil
+}
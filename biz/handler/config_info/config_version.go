@@ -4,7 +4,6 @@ import (
 	"confkeeper/biz/dal"
 	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 	"strconv"
 
@@ -22,6 +21,8 @@ type ListVersionData struct {
 	GroupId    string `json:"group_id"`
 	Type       string `json:"type"`
 	Content    string `json:"content"`
+	Md5        string `json:"md5"`
+	Sha256     string `json:"sha256"`
 	Version    string `json:"version"`
 	Author     string `json:"author"`
 	CreateTime string `json:"create_time"`
@@ -72,10 +73,10 @@ func ConfigVersion(c *gin.Context) {
 		return
 	}
 
-	// 权限检查：管理员或有命名空间r/rw权限的用户
-	if err := utils.IsAdmin(c); err != nil {
-		// 检查用户是否有命名空间的r或rw权限
-		hasPermission, err := mw.CheckNamespaceReadOrWritePermissionHTTP(c, configInfoData.TenantID)
+	// 权限检查：管理员或有该配置r/rw权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的r或rw权限
+		hasPermission, err := mw.CheckConfigReadPermissionHTTP(c, configInfoData.TenantID, configInfoData.GroupID, configInfoData.DataID)
 		if err != nil || !hasPermission {
 			c.JSON(http.StatusOK, &ListVersionResp{
 				Code: response.Code_Unauthorized,
@@ -104,6 +105,8 @@ func ConfigVersion(c *gin.Context) {
 			GroupId:    version.GroupID,
 			Version:    strconv.FormatUint(uint64(version.Version), 10),
 			Content:    version.Content,
+			Md5:        version.Md5,
+			Sha256:     version.Sha256,
 			Type:       version.Type,
 			Author:     version.Author,
 			CreateTime: version.CreateTime.Format("2006-01-02 15:04:05"),
@@ -0,0 +1,311 @@
+package config_info
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"confkeeper/utils/event_bus"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// 单个连接最多监听的配置数
+	watchMaxKeys = 200
+	// 心跳间隔，同时兜底重新比对所有配置，避免事件通道已满时丢失变更
+	watchHeartbeatInterval = 30 * time.Second
+
+	// WatchEventStale 建立连接时客户端已知的版本已过期
+	WatchEventStale = "stale"
+	// WatchEventPing 心跳，只用于 WebSocket，SSE 使用注释行
+	WatchEventPing = "ping"
+)
+
+var md5Pattern = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+
+type WatchReq struct {
+	// 监听的配置，格式为 tenant:group:dataId[@版本号或md5]，不带已知版本时建立连接后立即推送一次当前状态
+	Keys []string `form:"key" binding:"required,min=1,dive,min=1,max=1200"`
+}
+
+// WatchEventData 推送给客户端的配置变更
+type WatchEventData struct {
+	// stale: 建立连接时已过期，publish / delete / beta: 对应的变更事件，ping: 心跳
+	Type     string `json:"type"`
+	TenantId string `json:"tenant_id"`
+	GroupId  string `json:"group_id"`
+	DataId   string `json:"data_id"`
+	// 正式版本号，配置不存在时为0
+	Version int `json:"version"`
+	// 请求方应获取的内容的md5，命中灰度规则时为灰度内容的md5，配置不存在时为空
+	Md5 string `json:"md5"`
+	// 是否命中灰度规则
+	IsBeta bool   `json:"is_beta"`
+	Time   string `json:"time"`
+}
+
+// watchItem 客户端监听的配置和已知的状态
+type watchItem struct {
+	Tenant string
+	Group  string
+	DataId string
+	// 客户端已知的版本号和md5，都未知时建立连接后立即推送一次当前状态
+	Version    int
+	Md5        string
+	HasVersion bool
+	HasMd5     bool
+}
+
+// unchanged 配置的当前状态与已知的版本号和md5是否一致
+func (item *watchItem) unchanged(event *WatchEventData) bool {
+	if !item.HasVersion && !item.HasMd5 {
+		return false
+	}
+	if item.HasVersion && item.Version != event.Version {
+		return false
+	}
+	return !item.HasMd5 || item.Md5 == event.Md5
+}
+
+// ConfigWatch 监听配置变更
+//
+//	@Tags			配置
+//	@Summary		监听配置变更
+//	@Description	使用 SSE 推送配置变更，请求头带 Upgrade: websocket 时使用 WebSocket，每条消息为一个 WatchEventData
+//	@Description	key 格式为 tenant:group:dataId[@版本号或md5]，可以传多个，已知的版本或md5与当前不一致时建立连接后立即推送 stale 事件
+//	@Description	之后有变更时推送 publish / delete / beta 事件，需要每个配置的读取权限
+//	@Description	连接期间每次心跳重新校验登录状态和读取权限，失败时关闭连接
+//	@Accept			application/json
+//	@Produce		text/event-stream
+//	@Param			key				query		[]string	true	"监听的配置"	collectionFormat(multi)
+//	@Param			X-Config-Labels	header		string		false	"客户端标签(逗号分隔的key=value)，用于匹配灰度规则"
+//	@Success		200				{object}	WatchEventData
+//	@Failure		400				{string}	string	"参数错误"
+//	@Security		ApiKeyAuth
+//	@router			/api/config/watch [GET]
+func ConfigWatch(c *gin.Context) {
+	req := new(WatchReq)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(req.Keys) > watchMaxKeys {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_ValidateErr,
+			Msg:  "监听的配置数不能超过 " + strconv.Itoa(watchMaxKeys),
+		})
+		return
+	}
+
+	items := make([]*watchItem, 0, len(req.Keys))
+	for _, key := range req.Keys {
+		item, ok := parseWatchKey(key)
+		if !ok {
+			c.JSON(http.StatusOK, &response.CommonResp{
+				Code: response.Code_ValidateErr,
+				Msg:  "key 格式错误，应为 tenant:group:dataId[@版本号或md5]: " + key,
+			})
+			return
+		}
+		items = append(items, item)
+	}
+
+	// 权限检查：管理员或有每个监听配置r/rw权限的用户
+	if item := checkWatchPermission(c, items); item != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Unauthorized,
+			Msg:  "没有查看配置的权限: " + item.Tenant + ":" + item.Group + ":" + item.DataId,
+		})
+		return
+	}
+
+	if strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+		server := websocket.Server{
+			// 已通过 Authorization 请求头鉴权，不校验 Origin，方便非浏览器客户端连接
+			Handshake: func(*websocket.Config, *http.Request) error { return nil },
+			Handler: func(ws *websocket.Conn) {
+				defer ws.Close()
+				// 客户端不需要发送消息，读取只用于感知连接关闭
+				closed := make(chan struct{})
+				go func() {
+					defer close(closed)
+					var msg string
+					for websocket.Message.Receive(ws, &msg) == nil {
+					}
+				}()
+				watchConfigs(c, items, closed, func(event *WatchEventData) error {
+					return websocket.JSON.Send(ws, event)
+				})
+			},
+		}
+		server.ServeHTTP(c.Writer, c.Request)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// 避免反向代理缓冲
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	watchConfigs(c, items, c.Request.Context().Done(), func(event *WatchEventData) error {
+		if event.Type == WatchEventPing {
+			_, err := c.Writer.WriteString(": ping\n\n")
+			c.Writer.Flush()
+			return err
+		}
+		c.SSEvent(event.Type, event)
+		c.Writer.Flush()
+		return nil
+	})
+}
+
+// parseWatchKey 解析 tenant:group:dataId[@版本号或md5]，dataId 中可以包含冒号
+func parseWatchKey(key string) (*watchItem, bool) {
+	item := new(watchItem)
+	if i := strings.LastIndex(key, "@"); i >= 0 {
+		known := key[i+1:]
+		key = key[:i]
+		switch {
+		case known == "":
+		case md5Pattern.MatchString(known):
+			item.Md5 = strings.ToLower(known)
+			item.HasMd5 = true
+		default:
+			version, err := strconv.Atoi(known)
+			if err != nil || version < 0 {
+				return nil, false
+			}
+			item.Version = version
+			item.HasVersion = true
+		}
+	}
+
+	parts := strings.SplitN(key, ":", 3)
+	if len(parts) != 3 {
+		return nil, false
+	}
+	for _, part := range parts {
+		if part == "" || utils.HasWildcard(part) {
+			return nil, false
+		}
+	}
+	item.Tenant, item.Group, item.DataId = parts[0], parts[1], parts[2]
+	return item, true
+}
+
+// checkWatchPermission 检查是否有每个监听配置的读取权限，返回第一个没有权限的配置
+func checkWatchPermission(c *gin.Context, items []*watchItem) *watchItem {
+	if mw.IsAdmin(c) == nil {
+		return nil
+	}
+	for _, item := range items {
+		hasPermission, err := mw.CheckConfigReadPermissionHTTP(c, item.Tenant, item.Group, item.DataId)
+		if err != nil || !hasPermission {
+			return item
+		}
+	}
+	return nil
+}
+
+// watchConfigs 先推送已过期的配置，再推送变更事件，直到连接关闭、发送失败或重新鉴权失败
+func watchConfigs(c *gin.Context, items []*watchItem, closed <-chan struct{}, send func(*WatchEventData) error) {
+	// 先订阅再比对，避免比对与推送之间的变更被遗漏
+	events, cancel := event_bus.Subscribe()
+	defer cancel()
+
+	// check 比对配置的当前状态，与已知状态不一致时推送，之后以当前状态作为已知状态
+	check := func(item *watchItem, eventType string) bool {
+		event, err := currentWatchEvent(c, item, eventType)
+		if err != nil {
+			// 数据库错误时保留已知状态，等待下一次比对
+			return true
+		}
+		if !item.unchanged(event) {
+			if err := send(event); err != nil {
+				return false
+			}
+		}
+		item.Version, item.Md5 = event.Version, event.Md5
+		item.HasVersion, item.HasMd5 = true, true
+		return true
+	}
+
+	for _, item := range items {
+		if !check(item, WatchEventStale) {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(watchHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			for _, item := range items {
+				if item.DataId == event.DataID && item.Group == event.GroupID && item.Tenant == event.TenantID {
+					if !check(item, event.Type) {
+						return
+					}
+				}
+			}
+		case <-ticker.C:
+			// 连接期间会话可能过期或被吊销、用户可能被禁用或失去权限，每次心跳重新检查
+			if err := mw.Reauthenticate(c); err != nil {
+				return
+			}
+			if checkWatchPermission(c, items) != nil {
+				return
+			}
+			if err := send(&WatchEventData{Type: WatchEventPing, Time: time.Now().Format("2006-01-02 15:04:05")}); err != nil {
+				return
+			}
+			for _, item := range items {
+				if !check(item, WatchEventStale) {
+					return
+				}
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// currentWatchEvent 查询配置的当前状态，命中灰度规则时使用灰度内容的md5
+func currentWatchEvent(c *gin.Context, item *watchItem, eventType string) (*WatchEventData, error) {
+	event := &WatchEventData{
+		Type:     eventType,
+		TenantId: item.Tenant,
+		GroupId:  item.Group,
+		DataId:   item.DataId,
+		Time:     time.Now().Format("2006-01-02 15:04:05"),
+	}
+
+	configInfoData, err := dal.GetConfigInfoByDataIdAndGroupWithMaxVersion(item.DataId, item.Group, item.Tenant)
+	if err != nil {
+		return nil, err
+	}
+	if configInfoData == nil {
+		return event, nil
+	}
+
+	clientConfigData, err := resolveClientConfig(c, configInfoData)
+	if err != nil {
+		return nil, err
+	}
+	event.Version = configInfoData.Version
+	event.Md5 = clientConfigData.Md5
+	event.IsBeta = clientConfigData.IsBeta
+	return event, nil
+}
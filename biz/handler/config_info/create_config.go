@@ -6,7 +6,6 @@ import (
 	"confkeeper/biz/model"
 	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -37,10 +36,10 @@ func CreateConfig(c *gin.Context) {
 	}
 	resp := new(response.CommonResp)
 
-	// 权限检查：管理员或有命名空间rw权限的用户
-	if err := utils.IsAdmin(c); err != nil {
-		// 检查用户是否有命名空间的rw权限
-		hasPermission, err := mw.CheckNamespaceWritePermissionHTTP(c, req.TenantId)
+	// 权限检查：管理员或有该配置rw/w权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的rw或w权限
+		hasPermission, err := mw.CheckConfigWritePermissionHTTP(c, req.TenantId, req.GroupId, req.DataId)
 		if err != nil || !hasPermission {
 			c.JSON(http.StatusOK, &response.CommonResp{
 				Code: response.Code_Unauthorized,
@@ -101,6 +100,8 @@ func CreateConfig(c *gin.Context) {
 		return
 	}
 
+	auditConfig(c, model.AuditTargetConfig, "create", nil, cfg)
+
 	resp.Code = response.Code_Success
 	resp.Msg = "新建配置文件成功"
 
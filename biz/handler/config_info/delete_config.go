@@ -2,9 +2,9 @@ package config_info
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
 	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -50,10 +50,10 @@ func DeleteConfig(c *gin.Context) {
 		return
 	}
 
-	// 权限检查：管理员或有命名空间rw权限的用户
-	if err := utils.IsAdmin(c); err != nil {
-		// 检查用户是否有命名空间的rw权限
-		hasPermission, err := mw.CheckNamespaceWritePermissionHTTP(c, configInfoData.TenantID)
+	// 权限检查：管理员或有该配置rw/w权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的rw或w权限
+		hasPermission, err := mw.CheckConfigWritePermissionHTTP(c, configInfoData.TenantID, configInfoData.GroupID, configInfoData.DataID)
 		if err != nil || !hasPermission {
 			c.JSON(http.StatusOK, &response.CommonResp{
 				Code: response.Code_Unauthorized,
@@ -68,6 +68,8 @@ func DeleteConfig(c *gin.Context) {
 		return
 	}
 
+	auditConfig(c, model.AuditTargetConfig, "delete", configInfoData, nil)
+
 	resp.Code = response.Code_Success
 	resp.Msg = "配置删除成功"
 
@@ -4,7 +4,6 @@ import (
 	"confkeeper/biz/dal"
 	"confkeeper/biz/handler"
 	"confkeeper/biz/mw"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -23,12 +22,13 @@ type GetConfigByFileReq struct {
 //	@Description	获取配置(直接返回配置内容)
 //	@Accept			application/json
 //	@Produce		text/plain
-//	@Param			tenant	query		string	true	"租户"
-//	@Param			dataId	query		string	true	"数据ID"
-//	@Param			group	query		string	true	"分组"
-//	@Success		200		{string}	string	"配置内容"
-//	@Failure		404		{string}	string	"配置不存在"
-//	@Failure		500		{string}	string	"服务器错误"
+//	@Param			tenant			query		string	true	"租户"
+//	@Param			dataId			query		string	true	"数据ID"
+//	@Param			group			query		string	true	"分组"
+//	@Param			X-Config-Labels	header		string	false	"客户端标签(逗号分隔的key=value)，用于匹配灰度规则"
+//	@Success		200				{string}	string	"配置内容"
+//	@Failure		404				{string}	string	"配置不存在"
+//	@Failure		500				{string}	string	"服务器错误"
 //	@router			/api/config/get_by_file [GET]
 func GetConfigByFile(c *gin.Context) {
 	req := new(GetConfigByFileReq)
@@ -37,10 +37,10 @@ func GetConfigByFile(c *gin.Context) {
 		return
 	}
 
-	// 权限检查：管理员或有命名空间r/rw权限的用户
-	if err := utils.IsAdmin(c); err != nil {
-		// 检查用户是否有命名空间的r或rw权限
-		hasPermission, err := mw.CheckNamespaceReadOrWritePermissionHTTP(c, req.Tenant)
+	// 权限检查：管理员或有该配置r/rw权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的r或rw权限
+		hasPermission, err := mw.CheckConfigReadPermissionHTTP(c, req.Tenant, req.Group, req.DataId)
 		if err != nil || !hasPermission {
 			c.String(http.StatusUnauthorized, "没有查看配置的权限")
 			return
@@ -69,7 +69,14 @@ func GetConfigByFile(c *gin.Context) {
 		return
 	}
 
-	resp := configInfoData.Content
+	// 命中灰度规则的请求方获取灰度内容
+	clientConfigData, err := resolveClientConfig(c, configInfoData)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "数据库查询错误")
+		return
+	}
+
+	resp := clientConfigData.Content
 
 	// 直接返回配置内容
 	c.String(http.StatusOK, resp)
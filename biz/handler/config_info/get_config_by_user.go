@@ -4,7 +4,6 @@ import (
 	"confkeeper/biz/dal"
 	"confkeeper/biz/handler"
 	"confkeeper/biz/mw"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +12,8 @@ import (
 type GetConfigByUserReq struct {
 	Username string `form:"username" binding:"required,min=1,max=255"`
 	Password string `form:"password" binding:"required,min=1,max=255"`
+	// 用户启用了两步验证时必填
+	TotpCode string `form:"totp_code" binding:"max=20"`
 	Tenant   string `form:"tenant" binding:"required,min=1,max=100"`
 	DataId   string `form:"dataId" binding:"required,min=1,max=100"`
 	Group    string `form:"group" binding:"required,min=1,max=100"`
@@ -22,18 +23,20 @@ type GetConfigByUserReq struct {
 //
 //	@Tags			配置
 //	@Summary		直接使用账号获取配置
-//	@Description	直接使用账号获取配置
+//	@Description	直接使用账号获取配置，用户启用了两步验证时需要同时提交验证码，自动化场景建议使用 API Key
 //	@Accept			application/json
 //	@Produce		text/plain
-//	@Param			username	query		string	true	"用户名"
-//	@Param			password	query		string	true	"密码"
-//	@Param			tenant		query		string	true	"租户ID"
-//	@Param			dataId		query		string	true	"数据ID"
-//	@Param			group		query		string	true	"分组ID"
-//	@Success		200			{string}	string	"配置内容"
-//	@Failure		404			{string}	string	"配置不存在"
-//	@Failure		500			{string}	string	"服务器错误"
-//	@Success		200			{string}	string	""
+//	@Param			username		query		string	true	"用户名"
+//	@Param			password		query		string	true	"密码"
+//	@Param			totp_code		query		string	false	"两步验证码，用户启用了两步验证时必填"
+//	@Param			tenant			query		string	true	"租户ID"
+//	@Param			dataId			query		string	true	"数据ID"
+//	@Param			group			query		string	true	"分组ID"
+//	@Param			X-Config-Labels	header		string	false	"客户端标签(逗号分隔的key=value)，用于匹配灰度规则"
+//	@Success		200				{string}	string	"配置内容"
+//	@Failure		404				{string}	string	"配置不存在"
+//	@Failure		500				{string}	string	"服务器错误"
+//	@Success		200				{string}	string	""
 //	@router			/api/config/get_by_user [GET]
 func GetConfigByUser(c *gin.Context) {
 	req := new(GetConfigByUserReq)
@@ -42,22 +45,16 @@ func GetConfigByUser(c *gin.Context) {
 		return
 	}
 
-	userData, err := dal.UserLogin(req.Username)
-	if err != nil {
+	// 校验账号密码，启用了两步验证时同时校验验证码
+	if err := authenticateByUser(c, req.Username, req.Password, req.TotpCode); err != nil {
 		c.String(http.StatusOK, err.Error())
 		return
 	}
-	if utils.MD5(req.Password) != userData.Password {
-		c.String(http.StatusOK, "密码错误")
-		return
-	}
-	c.Set("userid", int(userData.ID))
-	c.Set("username", userData.Username)
 
-	// 权限检查：管理员或有命名空间r/rw权限的用户
-	if err := utils.IsAdmin(c); err != nil {
-		// 检查用户是否有命名空间的r或rw权限
-		hasPermission, err := mw.CheckNamespaceReadOrWritePermissionHTTP(c, req.Tenant)
+	// 权限检查：管理员或有该配置r/rw权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的r或rw权限
+		hasPermission, err := mw.CheckConfigReadPermissionHTTP(c, req.Tenant, req.Group, req.DataId)
 		if err != nil || !hasPermission {
 			c.String(http.StatusUnauthorized, "没有查看配置的权限")
 			return
@@ -86,7 +83,14 @@ func GetConfigByUser(c *gin.Context) {
 		return
 	}
 
-	resp := configInfoData.Content
+	// 命中灰度规则的请求方获取灰度内容
+	clientConfigData, err := resolveClientConfig(c, configInfoData)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "数据库查询错误")
+		return
+	}
+
+	resp := clientConfigData.Content
 
 	// 直接返回配置内容，符合nacos格式
 	c.String(http.StatusOK, resp)
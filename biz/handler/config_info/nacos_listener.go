@@ -0,0 +1,221 @@
+package config_info
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
+	"confkeeper/utils/event_bus"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// nacos 监听协议分隔符
+	nacosWordSeparator = "\x02"
+	nacosLineSeparator = "\x01"
+
+	// 默认长轮询超时时间(毫秒)
+	nacosDefaultPullingTimeout = 30000
+	// 提前返回的时间，避免客户端超时(毫秒)
+	nacosPullingDelay = 500
+	// 最小挂起时间(毫秒)
+	nacosMinPullingTimeout = 10000
+)
+
+type NacosListenerReq struct {
+	AccessToken      string `form:"accessToken" binding:"required,min=1"`
+	ListeningConfigs string `form:"Listening-Configs" binding:"required,min=1"`
+}
+
+// nacosListenItem 客户端监听的配置项
+type nacosListenItem struct {
+	DataId    string
+	Group     string
+	Md5       string
+	Tenant    string
+	HasTenant bool
+}
+
+// NacosListenConfig 监听配置变更(nacos兼容)
+//
+//	@Tags			配置
+//	@Tags			nacos兼容
+//	@Summary		监听配置变更(nacos兼容)
+//	@Description	长轮询监听配置变更，Listening-Configs格式为 dataId^2group^2md5^2tenant^1，有变更时立即返回变更的配置键
+//	@Accept			application/x-www-form-urlencoded
+//	@Produce		text/plain
+//	@Param			accessToken						query		string	true	"token"
+//	@Param			Listening-Configs				formData	string	true	"监听的配置"
+//	@Param			Long-Pulling-Timeout			header		int		false	"长轮询超时时间(毫秒)"	default(30000)
+//	@Param			Long-Pulling-Timeout-No-Hangup	header		bool	false	"没有变更时是否立即返回"
+//	@Param			X-Config-Labels					header		string	false	"客户端标签(逗号分隔的key=value)，用于匹配灰度规则"
+//	@Success		200								{string}	string	"变更的配置键"
+//	@Failure		401								{string}	string	"没有权限"
+//	@router			/nacos/v1/cs/configs/listener [POST]
+func NacosListenConfig(c *gin.Context) {
+	req := new(NacosListenerReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := mw.ValidateNacosToken(c, req.AccessToken)
+	if err != nil {
+		c.String(http.StatusUnauthorized, "token无效")
+		return
+	}
+
+	items := parseNacosListeningConfigs(req.ListeningConfigs)
+	if len(items) == 0 {
+		c.String(http.StatusBadRequest, "Listening-Configs格式错误")
+		return
+	}
+
+	// 权限检查：管理员或有每个监听配置r/rw权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		for _, item := range items {
+			// 检查用户是否有该配置的r或rw权限
+			hasPermission, err := mw.CheckConfigReadPermissionHTTP(c, item.Tenant, item.Group, item.DataId)
+			if err != nil || !hasPermission {
+				c.String(http.StatusUnauthorized, "没有查看配置的权限")
+				return
+			}
+		}
+	}
+
+	// 先订阅再比对，避免比对与挂起之间的变更被遗漏
+	events, cancel := event_bus.Subscribe()
+	defer cancel()
+
+	changed, err := nacosChangedItems(c, items)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "数据库查询错误")
+		return
+	}
+	if len(changed) > 0 || c.GetHeader("Long-Pulling-Timeout-No-Hangup") == "true" {
+		writeNacosListenResp(c, changed)
+		return
+	}
+
+	timeout := nacosDefaultPullingTimeout
+	if v, err := strconv.Atoi(c.GetHeader("Long-Pulling-Timeout")); err == nil && v > 0 {
+		timeout = v
+	}
+	timeout = max(nacosMinPullingTimeout, timeout-nacosPullingDelay)
+
+	timer := time.NewTimer(time.Duration(timeout) * time.Millisecond)
+	defer timer.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			if !isNacosListening(items, event) {
+				continue
+			}
+			changed, err = nacosChangedItems(c, items)
+			if err != nil {
+				c.String(http.StatusInternalServerError, "数据库查询错误")
+				return
+			}
+			if len(changed) > 0 {
+				writeNacosListenResp(c, changed)
+				return
+			}
+		case <-timer.C:
+			// 超时前重新比对一次，避免事件通道已满时丢失变更
+			changed, err = nacosChangedItems(c, items)
+			if err != nil {
+				c.String(http.StatusInternalServerError, "数据库查询错误")
+				return
+			}
+			writeNacosListenResp(c, changed)
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// parseNacosListeningConfigs 解析 dataId^2group^2md5[^2tenant]^1 格式的监听配置
+func parseNacosListeningConfigs(raw string) []*nacosListenItem {
+	var items []*nacosListenItem
+	for _, line := range strings.Split(raw, nacosLineSeparator) {
+		if line == "" {
+			continue
+		}
+		words := strings.Split(line, nacosWordSeparator)
+		if len(words) < 3 || len(words) > 4 {
+			return nil
+		}
+		item := &nacosListenItem{
+			DataId: words[0],
+			Group:  words[1],
+			Md5:    words[2],
+		}
+		if len(words) == 4 {
+			item.Tenant = words[3]
+			item.HasTenant = true
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// nacosChangedItems 返回md5与请求方应获取的版本(灰度或正式)不一致的配置项
+func nacosChangedItems(c *gin.Context, items []*nacosListenItem) ([]*nacosListenItem, error) {
+	var changed []*nacosListenItem
+	for _, item := range items {
+		configInfoData, err := dal.GetConfigInfoByDataIdAndGroupWithMaxVersion(item.DataId, item.Group, item.Tenant)
+		if err != nil {
+			return nil, err
+		}
+
+		// 配置不存在时md5视为空字符串
+		var md5 string
+		if configInfoData != nil {
+			clientConfigData, err := resolveClientConfig(c, configInfoData)
+			if err != nil {
+				return nil, err
+			}
+			md5 = clientConfigData.Md5
+		}
+		if md5 != item.Md5 {
+			changed = append(changed, item)
+		}
+	}
+	return changed, nil
+}
+
+// isNacosListening 判断事件是否命中监听的配置项
+func isNacosListening(items []*nacosListenItem, event event_bus.ConfigEvent) bool {
+	for _, item := range items {
+		if item.DataId == event.DataID && item.Group == event.GroupID && item.Tenant == event.TenantID {
+			return true
+		}
+	}
+	return false
+}
+
+// writeNacosListenResp 按 nacos 格式返回变更的配置键
+func writeNacosListenResp(c *gin.Context, changed []*nacosListenItem) {
+	var sb strings.Builder
+	for _, item := range changed {
+		sb.WriteString(item.DataId)
+		sb.WriteString(nacosWordSeparator)
+		sb.WriteString(item.Group)
+		if item.HasTenant {
+			sb.WriteString(nacosWordSeparator)
+			sb.WriteString(item.Tenant)
+		}
+		sb.WriteString(nacosLineSeparator)
+	}
+
+	c.Header("Pragma", "no-cache")
+	c.Header("Expires", "0")
+	c.Header("Cache-Control", "no-cache,no-store")
+	c.String(http.StatusOK, url.QueryEscape(sb.String()))
+}
@@ -0,0 +1,78 @@
+package config_info
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/handler"
+	"confkeeper/biz/model"
+	"confkeeper/biz/response"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PromoteBetaConfig 灰度转正
+//
+//	@Tags			配置
+//	@Summary		灰度转正
+//	@Description	将灰度内容发布为新的最大版本并结束灰度，所有客户端获取新版本
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			config_id	path		string	true	"配置ID"
+//	@Success		200			{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/config/beta/promote/{config_id} [POST]
+func PromoteBetaConfig(c *gin.Context) {
+	uriReq := new(BetaUriReq)
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	configInfoData, ok := getBetaTargetConfig(c, uriReq.ConfigId, "没有灰度转正的权限")
+	if !ok {
+		return
+	}
+
+	beta, err := dal.GetConfigInfoBeta(configInfoData.DataID, configInfoData.GroupID, configInfoData.TenantID)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if beta == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "该配置没有灰度版本",
+		})
+		return
+	}
+
+	promoted, err := dal.PromoteConfigInfoBeta(beta, c.GetString("username"))
+	if errors.Is(err, dal.ErrVersionConflict) {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Conflict,
+			Msg:  "灰度内容已被他人修改，请刷新后重试",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "灰度转正失败: " + err.Error(),
+		})
+		return
+	}
+
+	auditConfigBeta(c, "promote", beta, nil)
+	auditConfig(c, model.AuditTargetConfig, "promote_beta", nil, promoted)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "灰度转正成功"
+
+	c.JSON(http.StatusOK, resp)
+	handler.IncConfigChange()
+}
@@ -0,0 +1,156 @@
+package config_info
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/handler"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils/config"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PublishBetaReq struct {
+	Content    string   `json:"content" binding:"required"`
+	Type       *string  `json:"type" binding:"omitempty,min=1,max=255"`
+	BetaIps    []string `json:"beta_ips" binding:"omitempty,dive,min=1,max=64"`
+	BetaLabels []string `json:"beta_labels" binding:"omitempty,dive,min=1,max=255"`
+	// 跳过内容格式及Schema校验，仅管理员有效
+	Force bool `json:"force"`
+}
+
+type BetaUriReq struct {
+	ConfigId string `uri:"config_id" binding:"required"`
+}
+
+// PublishBetaConfig 灰度发布配置
+//
+//	@Tags			配置
+//	@Summary		灰度发布配置
+//	@Description	灰度内容与正式版本分开存储，只有客户端IP(支持CIDR)或 X-Config-Labels 请求头命中规则的请求方获取灰度内容，部署在反向代理后时需要配置 server.trusted_proxies 才能获取真实的客户端IP，重复发布时覆盖原灰度
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			config_id	path		string			true	"配置ID"
+//	@Param			req			body		PublishBetaReq	true	"灰度内容及规则"
+//	@Success		200			{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/config/beta/publish/{config_id} [POST]
+func PublishBetaConfig(c *gin.Context) {
+	req := new(PublishBetaReq)
+	uriReq := new(BetaUriReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	configInfoData, ok := getBetaTargetConfig(c, uriReq.ConfigId, "没有灰度发布配置的权限")
+	if !ok {
+		return
+	}
+
+	if len(req.BetaIps) == 0 && len(req.BetaLabels) == 0 {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "灰度IP和灰度标签不能同时为空",
+		})
+		return
+	}
+	betaIps, betaLabels, err := normalizeBetaRules(req.BetaIps, req.BetaLabels)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	beta := &model.ConfigInfoBeta{
+		DataID:     configInfoData.DataID,
+		GroupID:    configInfoData.GroupID,
+		TenantID:   configInfoData.TenantID,
+		Content:    req.Content,
+		Type:       configInfoData.Type,
+		BetaIps:    betaIps,
+		BetaLabels: betaLabels,
+		Author:     c.GetString("username"),
+	}
+	if req.Type != nil {
+		// 检查配置文件类型是否支持
+		if !slices.Contains(config.Cfg.Confkeeper.ConfigType, *req.Type) {
+			c.JSON(http.StatusOK, &response.CommonResp{
+				Code: response.Code_Err,
+				Msg:  "配置文件类型不支持",
+			})
+			return
+		}
+		beta.Type = *req.Type
+	}
+
+	// 校验内容格式及绑定的Schema
+	if !validateConfigContent(c, &model.ConfigInfo{
+		DataID:   beta.DataID,
+		GroupID:  beta.GroupID,
+		TenantID: beta.TenantID,
+		Content:  beta.Content,
+		Type:     beta.Type,
+	}, req.Force) {
+		return
+	}
+
+	if err = dal.SaveConfigInfoBeta(beta); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "灰度发布失败: " + err.Error(),
+		})
+		return
+	}
+
+	auditConfigBeta(c, "publish", nil, beta)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "灰度发布成功"
+
+	c.JSON(http.StatusOK, resp)
+	handler.IncConfigChange()
+}
+
+// getBetaTargetConfig 获取灰度操作的目标配置并检查写权限，失败时写入响应
+func getBetaTargetConfig(c *gin.Context, configId string, deniedMsg string) (*model.ConfigInfo, bool) {
+	configInfoData, err := dal.GetConfigInfoByID(configId)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return nil, false
+	}
+	if configInfoData == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "配置不存在",
+		})
+		return nil, false
+	}
+
+	// 权限检查：管理员或有该配置rw/w权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的rw或w权限
+		hasPermission, err := mw.CheckConfigWritePermissionHTTP(c, configInfoData.TenantID, configInfoData.GroupID, configInfoData.DataID)
+		if err != nil || !hasPermission {
+			c.JSON(http.StatusOK, &response.CommonResp{
+				Code: response.Code_Unauthorized,
+				Msg:  deniedMsg,
+			})
+			return nil, false
+		}
+	}
+	return configInfoData, true
+}
@@ -0,0 +1,161 @@
+package config_info
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/handler"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RollbackReq struct {
+	Version int `json:"version" binding:"required,min=1"`
+	// 跳过内容格式及Schema校验，仅管理员有效
+	Force bool `json:"force"`
+}
+
+type RollbackUriReq struct {
+	ConfigId string `uri:"config_id" binding:"required"`
+}
+
+// RollbackConfig 回滚配置
+//
+//	@Tags			配置
+//	@Summary		回滚配置
+//	@Description	将指定历史版本的内容发布为新的最大版本，历史版本保持不变
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			config_id	path		string		true	"配置ID"
+//	@Param			req			body		RollbackReq	true	"目标版本"
+//	@Success		200			{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/config/rollback/{config_id} [POST]
+func RollbackConfig(c *gin.Context) {
+	req := new(RollbackReq)
+	uriReq := new(RollbackUriReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	// 获取配置信息以检查权限
+	configInfoData, err := dal.GetConfigInfoByID(uriReq.ConfigId)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if configInfoData == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "配置不存在",
+		})
+		return
+	}
+
+	// 权限检查：管理员或有该配置rw/w权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的rw或w权限
+		hasPermission, err := mw.CheckConfigWritePermissionHTTP(c, configInfoData.TenantID, configInfoData.GroupID, configInfoData.DataID)
+		if err != nil || !hasPermission {
+			c.JSON(http.StatusOK, &response.CommonResp{
+				Code: response.Code_Unauthorized,
+				Msg:  "没有回滚配置的权限",
+			})
+			return
+		}
+	}
+
+	// 获取目标版本
+	targetConfig, err := dal.GetConfigInfoByVersion(configInfoData.DataID, configInfoData.GroupID, configInfoData.TenantID, req.Version)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if targetConfig == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "目标版本不存在",
+		})
+		return
+	}
+
+	// 获取当前data_id、group_id、tenant_id的最新版本
+	latestConfig, err := dal.GetConfigInfoByDataIdAndGroupWithMaxVersion(configInfoData.DataID, configInfoData.GroupID, configInfoData.TenantID)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if latestConfig == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "配置不存在",
+		})
+		return
+	}
+	maxVersion := latestConfig.Version
+	if targetConfig.Version == maxVersion {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "目标版本已是最新版本",
+		})
+		return
+	}
+
+	// 以目标版本内容创建新版本，历史版本保持不变
+	newConfig := &model.ConfigInfo{
+		DataID:   targetConfig.DataID,
+		GroupID:  targetConfig.GroupID,
+		Content:  targetConfig.Content,
+		TenantID: targetConfig.TenantID,
+		Type:     targetConfig.Type,
+		Author:   c.GetString("username"),
+	}
+
+	// 历史版本可能早于校验上线，回滚前同样校验内容格式
+	if !validateConfigContent(c, newConfig, req.Force) {
+		return
+	}
+
+	// 以查询到的最大版本作为基准，避免回滚覆盖期间他人的发布
+	err = dal.PublishConfigInfo(newConfig, &maxVersion, "")
+	if errors.Is(err, dal.ErrVersionConflict) {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Conflict,
+			Msg:  err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "回滚配置失败: " + err.Error(),
+		})
+		return
+	}
+
+	auditConfig(c, model.AuditTargetConfig, "rollback", latestConfig, newConfig)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "配置回滚成功"
+
+	c.JSON(http.StatusOK, resp)
+	handler.IncConfigChange()
+}
@@ -6,8 +6,8 @@ import (
 	"confkeeper/biz/model"
 	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"confkeeper/utils/config"
+	"errors"
 	"net/http"
 	"slices"
 
@@ -19,6 +19,11 @@ type UpdateReq struct {
 	GroupId *string `json:"group_id" binding:"omitempty,min=1,max=255"`
 	Content *string `json:"content" binding:"omitempty"`
 	Type    *string `json:"type" binding:"omitempty,min=1,max=255"`
+	// 乐观锁：期望的当前最新版本号或内容md5，不一致时拒绝发布
+	BaseVersion *int    `json:"base_version" binding:"omitempty,min=0"`
+	CasMd5      *string `json:"cas_md5" binding:"omitempty,len=32"`
+	// 跳过内容格式及Schema校验，仅管理员有效
+	Force bool `json:"force"`
 }
 
 type UpdateUriReq struct {
@@ -67,10 +72,10 @@ func UpdateConfig(c *gin.Context) {
 		return
 	}
 
-	// 权限检查：管理员或有命名空间rw权限的用户
-	if err := utils.IsAdmin(c); err != nil {
-		// 检查用户是否有命名空间的rw权限
-		hasPermission, err := mw.CheckNamespaceWritePermissionHTTP(c, configInfoData.TenantID)
+	// 权限检查：管理员或有该配置rw/w权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的rw或w权限
+		hasPermission, err := mw.CheckConfigWritePermissionHTTP(c, configInfoData.TenantID, configInfoData.GroupID, configInfoData.DataID)
 		if err != nil || !hasPermission {
 			c.JSON(http.StatusOK, &response.CommonResp{
 				Code: response.Code_Unauthorized,
@@ -80,25 +85,13 @@ func UpdateConfig(c *gin.Context) {
 		}
 	}
 
-	// 获取当前data_id、group_id、tenant_id的最大版本号
-	maxVersion, err := dal.GetMaxVersionByDataIdGroupAndTenant(configInfoData.DataID, configInfoData.GroupID, configInfoData.TenantID)
-	if err != nil {
-		c.JSON(http.StatusOK, &response.CommonResp{
-			Code: response.Code_DBErr,
-			Msg:  "数据库查询错误: " + err.Error(),
-		})
-		return
-	}
-
-	// 创建新版本配置
-	newVersion := maxVersion + 1
+	// 创建新版本配置，版本号在发布时取最大版本+1
 	newConfig := &model.ConfigInfo{
 		DataID:   configInfoData.DataID,
 		GroupID:  configInfoData.GroupID,
 		Content:  configInfoData.Content,
 		TenantID: configInfoData.TenantID,
 		Type:     configInfoData.Type,
-		Version:  newVersion,
 		Author:   c.GetString("username"),
 	}
 
@@ -124,8 +117,25 @@ func UpdateConfig(c *gin.Context) {
 		newConfig.Type = *req.Type
 	}
 
+	// 校验内容格式及绑定的Schema
+	if !validateConfigContent(c, newConfig, req.Force) {
+		return
+	}
+
+	var casMd5 string
+	if req.CasMd5 != nil {
+		casMd5 = *req.CasMd5
+	}
+
 	// 创建新配置记录
-	err = dal.CreateConfigInfo([]*model.ConfigInfo{newConfig})
+	err = dal.PublishConfigInfo(newConfig, req.BaseVersion, casMd5)
+	if errors.Is(err, dal.ErrVersionConflict) {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Conflict,
+			Msg:  err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, &response.CommonResp{
 			Code: response.Code_DBErr,
@@ -134,6 +144,8 @@ func UpdateConfig(c *gin.Context) {
 		return
 	}
 
+	auditConfig(c, model.AuditTargetConfig, "update", configInfoData, newConfig)
+
 	// 返回成功响应
 	resp.Code = response.Code_Success
 	resp.Msg = "配置信息更新成功"
@@ -6,7 +6,7 @@ import (
 	"confkeeper/biz/model"
 	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +18,11 @@ type UpdateByFileReq struct {
 	Group   string `form:"group" binding:"required,min=1,max=100"`
 	Type    string `form:"type" binding:"required,min=1,max=100"`
 	Content string `form:"content" binding:"required"`
+	// 乐观锁：期望的当前最新版本号或内容md5，不一致时拒绝发布
+	BaseVersion *int   `form:"baseVersion" binding:"omitempty,min=0"`
+	CasMd5      string `form:"casMd5" binding:"omitempty,len=32"`
+	// 跳过内容格式及Schema校验，仅管理员有效
+	Force bool `form:"force"`
 }
 
 // UpdateConfigByFile 更新/创建配置(文件上传)
@@ -27,12 +32,15 @@ type UpdateByFileReq struct {
 //	@Description	更新/创建配置(文件上传)
 //	@Accept			application/x-www-form-urlencoded
 //	@Produce		application/json
-//	@Param			tenant	formData	string	true	"tenant"
-//	@Param			dataId	formData	string	true	"dataId"
-//	@Param			group	formData	string	true	"group"
-//	@Param			type	formData	string	true	"type"
-//	@Param			content	formData	string	true	"content"
-//	@Success		200		{object}	response.CommonResp
+//	@Param			tenant		formData	string	true	"tenant"
+//	@Param			dataId		formData	string	true	"dataId"
+//	@Param			group		formData	string	true	"group"
+//	@Param			type		formData	string	true	"type"
+//	@Param			content		formData	string	true	"content"
+//	@Param			baseVersion	formData	int		false	"期望的当前最新版本号(0表示配置不存在)"
+//	@Param			casMd5		formData	string	false	"期望的当前最新内容md5"
+//	@Param			force		formData	bool	false	"跳过内容格式及Schema校验(仅管理员)"
+//	@Success		200			{object}	response.CommonResp
 //	@router			/api/config/update_by_file [POST]
 func UpdateConfigByFile(c *gin.Context) {
 	req := new(UpdateByFileReq)
@@ -42,10 +50,10 @@ func UpdateConfigByFile(c *gin.Context) {
 	}
 	resp := new(response.CommonResp)
 
-	// 权限检查：管理员或有命名空间rw权限的用户
-	if err := utils.IsAdmin(c); err != nil {
-		// 检查用户是否有命名空间的rw权限
-		hasPermission, err := mw.CheckNamespaceWritePermissionHTTP(c, req.Tenant)
+	// 权限检查：管理员或有该配置rw/w权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的rw或w权限
+		hasPermission, err := mw.CheckConfigWritePermissionHTTP(c, req.Tenant, req.Group, req.DataId)
 		if err != nil || !hasPermission {
 			c.JSON(http.StatusOK, &response.CommonResp{
 				Code: response.Code_Unauthorized,
@@ -72,44 +80,30 @@ func UpdateConfigByFile(c *gin.Context) {
 		return
 	}
 
-	// 判断该 tenant 下是否已存在该 dataId+group 的配置
-	exists, err := dal.IsConfigInfoExists(req.DataId, req.Group, req.Tenant)
-	if err != nil {
-		c.JSON(http.StatusOK, &response.CommonResp{
-			Code: response.Code_DBErr,
-			Msg:  "数据库查询错误: " + err.Error(),
-		})
-		return
-	}
-
-	var versionToCreate int
-	if !exists {
-		// 不存在则新增，version=1
-		versionToCreate = 1
-	} else {
-		// 已存在则在该 tenant 作用域下取最大版本+1
-		maxVersion, err := dal.GetMaxVersionByDataIdGroupAndTenant(req.DataId, req.Group, req.Tenant)
-		if err != nil {
-			c.JSON(http.StatusOK, &response.CommonResp{
-				Code: response.Code_DBErr,
-				Msg:  "数据库查询错误: " + err.Error(),
-			})
-			return
-		}
-		versionToCreate = maxVersion + 1
-	}
-
+	// 不存在则新增(version=1)，已存在则在该 tenant 作用域下取最大版本+1
 	cfg := &model.ConfigInfo{
 		DataID:   req.DataId,
 		GroupID:  req.Group,
 		Content:  req.Content,
 		TenantID: req.Tenant,
 		Type:     req.Type,
-		Version:  versionToCreate,
 		Author:   c.GetString("username"),
 	}
 
-	if err = dal.CreateConfigInfo([]*model.ConfigInfo{cfg}); err != nil {
+	// 校验内容格式及绑定的Schema
+	if !validateConfigContent(c, cfg, req.Force) {
+		return
+	}
+
+	err = dal.PublishConfigInfo(cfg, req.BaseVersion, req.CasMd5)
+	if errors.Is(err, dal.ErrVersionConflict) {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Conflict,
+			Msg:  err.Error(),
+		})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_DBErr,
 			Msg:  "创建配置失败: " + err.Error(),
@@ -117,6 +111,8 @@ func UpdateConfigByFile(c *gin.Context) {
 		return
 	}
 
+	auditConfig(c, model.AuditTargetConfig, "publish", nil, cfg)
+
 	resp.Code = response.Code_Success
 	resp.Msg = "上传成功"
 
@@ -6,7 +6,7 @@ import (
 	"confkeeper/biz/model"
 	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -15,18 +15,22 @@ import (
 type UpdateConfigByUserReq struct {
 	Username string `form:"username" binding:"required,min=1,max=255"`
 	Password string `form:"password" binding:"required,min=1,max=255"`
+	// 用户启用了两步验证时必填
+	TotpCode string `form:"totp_code" binding:"max=20"`
 	Tenant   string `form:"tenant" binding:"required,min=1,max=100"`
 	DataId   string `form:"dataId" binding:"required,min=1,max=100"`
 	Group    string `form:"group" binding:"required,min=1,max=100"`
 	Type     string `form:"type" binding:"required,min=1,max=100"`
 	Content  string `form:"content" binding:"required"`
+	// 跳过内容格式及Schema校验，仅管理员有效
+	Force bool `form:"force"`
 }
 
 // UpdateConfigByUser 使用账号更新/创建配置
 //
 //	@Tags			配置
 //	@Summary		使用账号更新/创建配置
-//	@Description	使用账号更新/创建配置
+//	@Description	使用账号更新/创建配置，用户启用了两步验证时需要同时提交验证码
 //	@Accept			application/x-www-form-urlencoded
 //	@Produce		application/json
 //	@Param			accessToken	query		string	true	"token"
@@ -35,6 +39,8 @@ type UpdateConfigByUserReq struct {
 //	@Param			group		formData	string	true	"group"
 //	@Param			type		formData	string	true	"type"
 //	@Param			content		formData	string	true	"content"
+//	@Param			force		formData	bool	false	"跳过内容格式及Schema校验(仅管理员)"
+//	@Param			totp_code	formData	string	false	"两步验证码，用户启用了两步验证时必填"
 //	@Success		200			{object}	response.CommonResp
 //	@router			/api/config/update_by_user [POST]
 func UpdateConfigByUser(c *gin.Context) {
@@ -45,22 +51,16 @@ func UpdateConfigByUser(c *gin.Context) {
 	}
 	resp := new(response.CommonResp)
 
-	userData, err := dal.UserLogin(req.Username)
-	if err != nil {
+	// 校验账号密码，启用了两步验证时同时校验验证码
+	if err := authenticateByUser(c, req.Username, req.Password, req.TotpCode); err != nil {
 		c.String(http.StatusOK, err.Error())
 		return
 	}
-	if utils.MD5(req.Password) != userData.Password {
-		c.String(http.StatusOK, "密码错误")
-		return
-	}
-	c.Set("userid", int(userData.ID))
-	c.Set("username", userData.Username)
 
-	// 权限检查：管理员或有命名空间rw权限的用户
-	if err := utils.IsAdmin(c); err != nil {
-		// 检查用户是否有命名空间的rw权限
-		hasPermission, err := mw.CheckNamespaceWritePermissionHTTP(c, req.Tenant)
+	// 权限检查：管理员或有该配置rw/w权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的rw或w权限
+		hasPermission, err := mw.CheckConfigWritePermissionHTTP(c, req.Tenant, req.Group, req.DataId)
 		if err != nil || !hasPermission {
 			c.JSON(http.StatusOK, &response.CommonResp{
 				Code: response.Code_Unauthorized,
@@ -87,44 +87,30 @@ func UpdateConfigByUser(c *gin.Context) {
 		return
 	}
 
-	// 判断该 tenant 下是否已存在该 dataId+group 的配置
-	exists, err := dal.IsConfigInfoExists(req.DataId, req.Group, req.Tenant)
-	if err != nil {
-		c.JSON(http.StatusOK, &response.CommonResp{
-			Code: response.Code_DBErr,
-			Msg:  "数据库查询错误: " + err.Error(),
-		})
-		return
-	}
-
-	var versionToCreate int
-	if !exists {
-		// 不存在则新增，version=1
-		versionToCreate = 1
-	} else {
-		// 已存在则在该 tenant 作用域下取最大版本+1
-		maxVersion, err := dal.GetMaxVersionByDataIdGroupAndTenant(req.DataId, req.Group, req.Tenant)
-		if err != nil {
-			c.JSON(http.StatusOK, &response.CommonResp{
-				Code: response.Code_DBErr,
-				Msg:  "数据库查询错误: " + err.Error(),
-			})
-			return
-		}
-		versionToCreate = maxVersion + 1
-	}
-
+	// 不存在则新增(version=1)，已存在则在该 tenant 作用域下取最大版本+1
 	cfg := &model.ConfigInfo{
 		DataID:   req.DataId,
 		GroupID:  req.Group,
 		Content:  req.Content,
 		TenantID: req.Tenant,
 		Type:     req.Type,
-		Version:  versionToCreate,
 		Author:   c.GetString("username"),
 	}
 
-	if err = dal.CreateConfigInfo([]*model.ConfigInfo{cfg}); err != nil {
+	// 校验内容格式及绑定的Schema
+	if !validateConfigContent(c, cfg, req.Force) {
+		return
+	}
+
+	err = dal.PublishConfigInfo(cfg, nil, "")
+	if errors.Is(err, dal.ErrVersionConflict) {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Conflict,
+			Msg:  err.Error(),
+		})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_DBErr,
 			Msg:  "创建配置失败: " + err.Error(),
@@ -132,6 +118,8 @@ func UpdateConfigByUser(c *gin.Context) {
 		return
 	}
 
+	auditConfig(c, model.AuditTargetConfig, "publish", nil, cfg)
+
 	resp.Code = response.Code_Success
 	resp.Msg = "上传成功"
 
@@ -0,0 +1,115 @@
+package config_schema
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils/config_schema"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreateReq struct {
+	DataId   string `json:"data_id" binding:"required,min=1,max=255"`
+	GroupId  string `json:"group_id" binding:"required,min=1,max=255"`
+	TenantId string `json:"tenant_id" binding:"required,min=1,max=255"`
+	Content  string `json:"content" binding:"required"`
+}
+
+// CreateSchema 创建配置Schema
+//
+//	@Tags			配置Schema
+//	@Summary		创建配置Schema
+//	@Description	为 dataId/group/tenant 绑定 JSON Schema，发布 json/yaml/toml 配置时按该 schema 校验
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			req	body		CreateReq	true	"Schema信息"
+//	@Success		200	{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/schema/add [PUT]
+func CreateSchema(c *gin.Context) {
+	req := new(CreateReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	// 权限检查：管理员或有该配置rw/w权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的rw或w权限
+		hasPermission, err := mw.CheckConfigWritePermissionHTTP(c, req.TenantId, req.GroupId, req.DataId)
+		if err != nil || !hasPermission {
+			c.JSON(http.StatusOK, &response.CommonResp{
+				Code: response.Code_Unauthorized,
+				Msg:  "没有创建配置Schema的权限",
+			})
+			return
+		}
+	}
+
+	// 检查命名空间是否存在
+	exist, err := dal.IsTenantIdExists(req.TenantId)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "检查命名空间失败: " + err.Error(),
+		})
+		return
+	}
+	if !exist {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "该命名空间不存在",
+		})
+		return
+	}
+
+	// 检查是否已绑定schema
+	exist, err = dal.IsConfigSchemaExists(req.DataId, req.GroupId, req.TenantId)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "检查配置Schema失败: " + err.Error(),
+		})
+		return
+	}
+	if exist {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_AlreadyExists,
+			Msg:  "该配置已绑定Schema",
+		})
+		return
+	}
+
+	// 检查schema是否合法
+	if _, err := config_schema.Compile(req.Content); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "Schema不合法: " + err.Error(),
+		})
+		return
+	}
+
+	schema := &model.ConfigSchema{
+		DataID:   req.DataId,
+		GroupID:  req.GroupId,
+		TenantID: req.TenantId,
+		Content:  req.Content,
+		Author:   c.GetString("username"),
+	}
+
+	if err = dal.CreateConfigSchema(schema); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_DBErr, Msg: "配置Schema新建失败: " + err.Error()})
+		return
+	}
+
+	auditSchema(c, "create", schema, nil, &schema.Content)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "新建配置Schema成功"
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,74 @@
+package config_schema
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteSchema 删除配置Schema
+//
+//	@Tags			配置Schema
+//	@Summary		删除配置Schema
+//	@Description	删除配置Schema，删除后发布不再校验结构
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			id	path		int	true	"SchemaID"
+//	@Success		200	{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/schema/delete/{id} [DELETE]
+func DeleteSchema(c *gin.Context) {
+	uriReq := new(UriReq)
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	schema, err := dal.GetConfigSchemaByID(uriReq.ID)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if schema == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "配置Schema不存在",
+		})
+		return
+	}
+
+	// 权限检查：管理员或有该配置rw/w权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的rw或w权限
+		hasPermission, err := mw.CheckConfigWritePermissionHTTP(c, schema.TenantID, schema.GroupID, schema.DataID)
+		if err != nil || !hasPermission {
+			c.JSON(http.StatusOK, &response.CommonResp{
+				Code: response.Code_Unauthorized,
+				Msg:  "没有删除配置Schema的权限",
+			})
+			return
+		}
+	}
+
+	if err = dal.DeleteConfigSchema(schema.ID); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "配置Schema删除失败: " + err.Error(),
+		})
+		return
+	}
+
+	auditSchema(c, "delete", schema, &schema.Content, nil)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "配置Schema删除成功"
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,79 @@
+package config_schema
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetReq struct {
+	DataId   string `form:"data_id" binding:"required,min=1,max=255"`
+	GroupId  string `form:"group_id" binding:"required,min=1,max=255"`
+	TenantId string `form:"tenant_id" binding:"required,min=1,max=255"`
+}
+
+type GetResp struct {
+	Code response.Code `json:"code"`
+	Msg  string        `json:"msg"`
+	Data *SchemaData   `json:"data"`
+}
+
+// GetSchema 获取配置绑定的Schema
+//
+//	@Tags			配置Schema
+//	@Summary		获取配置绑定的Schema
+//	@Description	根据 dataId/group/tenant 获取绑定的 JSON Schema
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			data_id		query		string	true	"配置id"
+//	@Param			group_id	query		string	true	"组id"
+//	@Param			tenant_id	query		string	true	"命名空间id"
+//	@Success		200			{object}	GetResp
+//	@Security		ApiKeyAuth
+//	@router			/api/schema/get [GET]
+func GetSchema(c *gin.Context) {
+	req := new(GetReq)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(GetResp)
+
+	// 权限检查：管理员或有该配置r/rw权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的r或rw权限
+		hasPermission, err := mw.CheckConfigReadPermissionHTTP(c, req.TenantId, req.GroupId, req.DataId)
+		if err != nil || !hasPermission {
+			c.JSON(http.StatusOK, &GetResp{
+				Code: response.Code_Unauthorized,
+				Msg:  "没有查看配置Schema的权限",
+			})
+			return
+		}
+	}
+
+	schema, err := dal.GetConfigSchema(req.DataId, req.GroupId, req.TenantId)
+	if err != nil {
+		c.JSON(http.StatusOK, &GetResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if schema == nil {
+		c.JSON(http.StatusOK, &GetResp{
+			Code: response.Code_Err,
+			Msg:  "该配置未绑定Schema",
+		})
+		return
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "获取成功"
+	resp.Data = toSchemaData(schema)
+
+	c.JSON(http.StatusOK, resp)
+}
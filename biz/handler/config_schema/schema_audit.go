@@ -0,0 +1,27 @@
+package config_schema
+
+import (
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// schemaAuditSummary Schema的审计摘要，只记录内容的md5
+type schemaAuditSummary struct {
+	Md5 string `json:"md5"`
+}
+
+// auditSchema 记录Schema的变更，before/after 为空表示新建/删除
+func auditSchema(c *gin.Context, action string, schema *model.ConfigSchema, beforeContent *string, afterContent *string) {
+	resource := utils.Resource{Tenant: schema.TenantID, Group: schema.GroupID, DataId: schema.DataID}
+	mw.Audit(c, model.AuditTargetSchema, action, resource.String(), newSchemaAuditSummary(beforeContent), newSchemaAuditSummary(afterContent))
+}
+
+func newSchemaAuditSummary(content *string) interface{} {
+	if content == nil {
+		return nil
+	}
+	return &schemaAuditSummary{Md5: utils.MD5(*content)}
+}
@@ -0,0 +1,115 @@
+package config_schema
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListReq struct {
+	Page     int32   `form:"page" binding:"required,min=1,max=1000"`
+	PageSize int32   `form:"page_size" binding:"required,min=1,max=100"`
+	DataId   *string `form:"data_id" binding:"omitempty,min=1,max=255"`
+	GroupId  *string `form:"group_id" binding:"omitempty,min=1,max=255"`
+	TenantId string  `form:"tenant_id" binding:"required,min=1,max=100"`
+}
+
+type SchemaData struct {
+	ID         uint   `json:"id"`
+	DataId     string `json:"data_id"`
+	GroupId    string `json:"group_id"`
+	TenantId   string `json:"tenant_id"`
+	Content    string `json:"content"`
+	Author     string `json:"author"`
+	CreateTime string `json:"create_time"`
+	UpdateTime string `json:"update_time"`
+}
+
+type ListResp struct {
+	Code  response.Code `json:"code"`
+	Msg   string        `json:"msg"`
+	Total int64         `json:"total"`
+	Data  []*SchemaData `json:"data"`
+}
+
+// SchemaList 配置Schema列表
+//
+//	@Tags			配置Schema
+//	@Summary		配置Schema列表
+//	@Description	配置Schema列表
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			page		query		int		false	"页码"	default(1)
+//	@Param			page_size	query		int		false	"每页数量"	default(10)
+//	@Param			tenant_id	query		string	true	"命名空间id"
+//	@Param			data_id		query		string	false	"配置id"
+//	@Param			group_id	query		string	false	"组id"
+//	@Success		200			{object}	ListResp
+//	@Security		ApiKeyAuth
+//	@router			/api/schema/list [GET]
+func SchemaList(c *gin.Context) {
+	req := new(ListReq)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(ListResp)
+
+	// 权限检查：管理员或有命名空间r/rw权限的用户，只返回用户有权限的配置
+	scope, err := mw.NamespaceReadScopeHTTP(c, req.TenantId)
+	if err != nil {
+		c.JSON(http.StatusOK, &ListResp{
+			Code: response.Code_Unauthorized,
+			Msg:  "没有查看配置Schema的权限",
+		})
+		return
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+
+	var DataId, GroupId string
+	if req.DataId != nil {
+		DataId = *req.DataId
+	}
+	if req.GroupId != nil {
+		GroupId = *req.GroupId
+	}
+
+	schemas, total, err := dal.GetConfigSchemaList(int(req.PageSize), int(offset), DataId, GroupId, req.TenantId, scope)
+	if err != nil {
+		c.JSON(http.StatusOK, &ListResp{
+			Code: response.Code_DBErr,
+			Msg:  "获取配置Schema列表失败: " + err.Error(),
+		})
+		return
+	}
+
+	var schemaList []*SchemaData
+	for _, s := range schemas {
+		schemaList = append(schemaList, toSchemaData(s))
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "获取成功"
+	resp.Total = total
+	resp.Data = schemaList
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func toSchemaData(schema *model.ConfigSchema) *SchemaData {
+	return &SchemaData{
+		ID:         schema.ID,
+		DataId:     schema.DataID,
+		GroupId:    schema.GroupID,
+		TenantId:   schema.TenantID,
+		Content:    schema.Content,
+		Author:     schema.Author,
+		CreateTime: schema.CreateTime.Format("2006-01-02 15:04:05"),
+		UpdateTime: schema.UpdateTime.Format("2006-01-02 15:04:05"),
+	}
+}
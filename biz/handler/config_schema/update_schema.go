@@ -0,0 +1,101 @@
+package config_schema
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils/config_schema"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateReq struct {
+	Content string `json:"content" binding:"required"`
+}
+
+type UriReq struct {
+	ID uint `uri:"id" binding:"required,min=1"`
+}
+
+// UpdateSchema 更新配置Schema
+//
+//	@Tags			配置Schema
+//	@Summary		更新配置Schema
+//	@Description	更新配置Schema，已发布的配置不会重新校验
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			id	path		int			true	"SchemaID"
+//	@Param			req	body		UpdateReq	true	"Schema内容"
+//	@Success		200	{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/schema/update/{id} [POST]
+func UpdateSchema(c *gin.Context) {
+	req := new(UpdateReq)
+	uriReq := new(UriReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	schema, err := dal.GetConfigSchemaByID(uriReq.ID)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if schema == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "配置Schema不存在",
+		})
+		return
+	}
+
+	// 权限检查：管理员或有该配置rw/w权限的用户
+	if err := mw.IsAdmin(c); err != nil {
+		// 检查用户是否有该配置的rw或w权限
+		hasPermission, err := mw.CheckConfigWritePermissionHTTP(c, schema.TenantID, schema.GroupID, schema.DataID)
+		if err != nil || !hasPermission {
+			c.JSON(http.StatusOK, &response.CommonResp{
+				Code: response.Code_Unauthorized,
+				Msg:  "没有更新配置Schema的权限",
+			})
+			return
+		}
+	}
+
+	// 检查schema是否合法
+	if _, err := config_schema.Compile(req.Content); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "Schema不合法: " + err.Error(),
+		})
+		return
+	}
+
+	beforeContent := schema.Content
+	schema.Content = req.Content
+	schema.Author = c.GetString("username")
+	if err = dal.UpdateConfigSchema(schema); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "配置Schema更新失败: " + err.Error(),
+		})
+		return
+	}
+
+	auditSchema(c, "update", schema, &beforeContent, &schema.Content)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "配置Schema更新成功"
+
+	c.JSON(http.StatusOK, resp)
+}
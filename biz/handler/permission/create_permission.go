@@ -2,6 +2,8 @@ package permission
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
 	"confkeeper/utils"
 	"confkeeper/utils/config"
@@ -12,16 +14,19 @@ import (
 )
 
 type CreateReq struct {
-	Role     string `json:"role" binding:"required,min=1,max=255"`
+	Role string `json:"role" binding:"required,min=1,max=255"`
+	// 资源 tenant:group:dataId，每一段都支持通配符 * 和 ?，只写 tenant 时表示整个命名空间，例如 prod:payment-*:*
 	Resource string `json:"resource" binding:"required,min=1,max=255"`
 	Action   string `json:"action" binding:"required,min=1,max=255"`
+	// 效果 allow(默认) 或 deny，拒绝规则优先于允许规则，例如允许 prod 的同时拒绝 prod:secrets:*
+	Effect string `json:"effect" binding:"omitempty,oneof=allow deny"`
 }
 
 // CreatePermission 创建权限
 //
 //	@Tags			权限管理
 //	@Summary		创建权限
-//	@Description	为角色分配权限
+//	@Description	为角色分配权限，资源格式为 tenant:group:dataId，支持通配符 * 和 ?；effect 为 deny 时为拒绝规则，优先于允许规则
 //	@Accept			application/json
 //	@Produce		application/json
 //	@Param			req	body		CreateReq	true	"权限信息"
@@ -37,7 +42,7 @@ func CreatePermission(c *gin.Context) {
 	resp := new(response.CommonResp)
 
 	// 检查是否为管理员
-	err := utils.IsAdmin(c)
+	err := mw.IsAdmin(c)
 	if err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_Unauthorized,
@@ -72,15 +77,28 @@ func CreatePermission(c *gin.Context) {
 		return
 	}
 
-	// 检查命名空间是否已存在
-	exist, err := dal.IsTenantIdExists(req.Resource)
+	// 检查资源格式
+	resource, err := utils.ParseResource(req.Resource)
 	if err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
-			Code: response.Code_DBErr,
-			Msg:  "检查命名空间失败: " + err.Error(),
+			Code: response.Code_ValidateErr,
+			Msg:  err.Error(),
 		})
 		return
 	}
+
+	// 检查命名空间是否已存在，使用通配符时可以匹配之后创建的命名空间
+	exist := true
+	if !utils.HasWildcard(resource.Tenant) {
+		exist, err = dal.IsTenantIdExists(resource.Tenant)
+		if err != nil {
+			c.JSON(http.StatusOK, &response.CommonResp{
+				Code: response.Code_DBErr,
+				Msg:  "检查命名空间失败: " + err.Error(),
+			})
+			return
+		}
+	}
 	if !exist {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_AlreadyExists,
@@ -106,12 +124,23 @@ func CreatePermission(c *gin.Context) {
 		return
 	}
 
+	if req.Effect == "" {
+		req.Effect = model.PermissionEffectAllow
+	}
+
 	// 创建权限
-	if err = dal.AddRolePermission(req.Role, req.Resource, req.Action); err != nil {
+	if err = dal.AddRolePermission(req.Role, req.Resource, req.Action, req.Effect); err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_DBErr, Msg: "权限创建失败: " + err.Error()})
 		return
 	}
 
+	mw.Audit(c, model.AuditTargetPermission, "create", req.Role, nil, &model.Permissions{
+		Role:     req.Role,
+		Resource: req.Resource,
+		Action:   req.Action,
+		Effect:   req.Effect,
+	})
+
 	resp.Code = response.Code_Success
 	resp.Msg = "创建权限成功"
 
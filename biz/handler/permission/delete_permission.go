@@ -2,8 +2,9 @@ package permission
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -37,7 +38,7 @@ func DeletePermission(c *gin.Context) {
 	resp := new(response.CommonResp)
 
 	// 检查是否为管理员
-	err := utils.IsAdmin(c)
+	err := mw.IsAdmin(c)
 	if err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_Unauthorized,
@@ -69,6 +70,11 @@ func DeletePermission(c *gin.Context) {
 		return
 	}
 
+	mw.Audit(c, model.AuditTargetPermission, "delete", req.Role, map[string]string{
+		"resource": req.Resource,
+		"action":   req.Action,
+	}, nil)
+
 	resp.Code = response.Code_Success
 	resp.Msg = "删除权限成功"
 
@@ -0,0 +1,139 @@
+package permission
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExplainReq struct {
+	Username string `json:"username" binding:"required,min=1,max=255"`
+	// 具体的配置 tenant:group:dataId，不能使用通配符
+	Resource string `json:"resource" binding:"required,min=1,max=255"`
+	// r: 读取，w: 写入，rw: 同时读取和写入
+	Action string `json:"action" binding:"required,oneof=r w rw"`
+}
+
+type ExplainData struct {
+	Allowed bool `json:"allowed"`
+	// 判定原因
+	Reason  string   `json:"reason"`
+	IsAdmin bool     `json:"is_admin"`
+	Roles   []string `json:"roles"`
+	// 与配置和操作匹配的允许规则
+	AllowRules []*ListData `json:"allow_rules"`
+	// 与配置和操作匹配的拒绝规则，只要有一条就不允许
+	DenyRules []*ListData `json:"deny_rules"`
+}
+
+type ExplainResp struct {
+	Code response.Code `json:"code"`
+	Msg  string        `json:"msg"`
+	Data *ExplainData  `json:"data"`
+}
+
+// ExplainPermission 权限判定说明
+//
+//	@Tags			权限管理
+//	@Summary		权限判定说明
+//	@Description	判定用户对某个配置是否有指定操作的权限，并返回匹配的允许规则和拒绝规则
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			req	body		ExplainReq	true	"用户、配置和操作"
+//	@Success		200	{object}	ExplainResp
+//	@Security		ApiKeyAuth
+//	@router			/api/permission/explain [POST]
+func ExplainPermission(c *gin.Context) {
+	req := new(ExplainReq)
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(ExplainResp)
+
+	// 检查是否为管理员
+	if err := mw.IsAdmin(c); err != nil {
+		c.JSON(http.StatusOK, &ExplainResp{
+			Code: response.Code_Unauthorized,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	resource, err := utils.ParseResource(req.Resource)
+	if err != nil || utils.HasWildcard(resource.String()) {
+		c.JSON(http.StatusOK, &ExplainResp{
+			Code: response.Code_ValidateErr,
+			Msg:  "resource 需要是具体的配置 tenant:group:dataId，不能使用通配符",
+		})
+		return
+	}
+
+	userData, err := dal.UserLogin(req.Username)
+	if err != nil {
+		c.JSON(http.StatusOK, &ExplainResp{
+			Code: response.Code_DBErr,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	roles, err := dal.PermissionService.GetUserRoles(userData.Username)
+	if err != nil {
+		c.JSON(http.StatusOK, &ExplainResp{
+			Code: response.Code_DBErr,
+			Msg:  "查询用户角色失败: " + err.Error(),
+		})
+		return
+	}
+
+	decision, err := dal.PermissionService.ExplainConfigPermission(userData.Username, resource.Tenant, resource.Group, resource.DataId, req.Action)
+	if err != nil {
+		c.JSON(http.StatusOK, &ExplainResp{
+			Code: response.Code_DBErr,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	data := &ExplainData{
+		Allowed:    decision.Allowed,
+		IsAdmin:    userData.IsAdmin,
+		Roles:      roles,
+		AllowRules: newListDataList(decision.AllowRules),
+		DenyRules:  newListDataList(decision.DenyRules),
+	}
+	switch {
+	case !userData.Enable:
+		data.Allowed = false
+		data.Reason = "用户已禁用"
+	case userData.IsAdmin:
+		data.Allowed = true
+		data.Reason = "管理员拥有所有配置的权限"
+	case len(decision.DenyRules) > 0:
+		data.Reason = "匹配到拒绝规则，拒绝优先于允许"
+	case decision.Allowed:
+		data.Reason = "匹配到允许规则"
+	default:
+		data.Reason = "没有匹配的允许规则"
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "获取成功"
+	resp.Data = data
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func newListDataList(permissions []*model.Permissions) []*ListData {
+	list := make([]*ListData, 0, len(permissions))
+	for _, p := range permissions {
+		list = append(list, newListData(p))
+	}
+	return list
+}
@@ -2,8 +2,9 @@ package permission
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -19,6 +20,7 @@ type ListData struct {
 	Role     string `json:"role"`
 	Action   string `json:"action"`
 	Resource string `json:"resource"`
+	Effect   string `json:"effect"`
 }
 
 type ListResp struct {
@@ -50,7 +52,7 @@ func PermissionList(c *gin.Context) {
 	resp := new(ListResp)
 
 	// 检查是否为管理员
-	err := utils.IsAdmin(c)
+	err := mw.IsAdmin(c)
 	if err != nil {
 		c.JSON(http.StatusOK, &ListResp{
 			Code: response.Code_Unauthorized,
@@ -79,11 +81,7 @@ func PermissionList(c *gin.Context) {
 
 	var permissionList []*ListData
 	for _, p := range permissions {
-		permissionList = append(permissionList, &ListData{
-			Role:     p.Role,
-			Resource: p.Resource,
-			Action:   p.Action,
-		})
+		permissionList = append(permissionList, newListData(p))
 	}
 
 	resp.Code = response.Code_Success
@@ -93,3 +91,12 @@ func PermissionList(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+func newListData(p *model.Permissions) *ListData {
+	return &ListData{
+		Role:     p.Role,
+		Resource: p.Resource,
+		Action:   p.Action,
+		Effect:   p.Effect,
+	}
+}
@@ -3,8 +3,8 @@ package role
 import (
 	"confkeeper/biz/dal"
 	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -35,7 +35,7 @@ func CreateRole(c *gin.Context) {
 	resp := new(response.CommonResp)
 
 	// 检查是否为管理员
-	err := utils.IsAdmin(c)
+	err := mw.IsAdmin(c)
 	if err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_Unauthorized,
@@ -88,6 +88,8 @@ func CreateRole(c *gin.Context) {
 		return
 	}
 
+	mw.Audit(c, model.AuditTargetRole, "create", r.Role, nil, r)
+
 	resp.Code = response.Code_Success
 	resp.Msg = "创建角色成功"
 
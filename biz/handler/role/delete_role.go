@@ -2,8 +2,9 @@ package role
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -33,7 +34,7 @@ func DeleteRole(c *gin.Context) {
 	resp := new(response.CommonResp)
 
 	// 检查是否为管理员
-	err := utils.IsAdmin(c)
+	err := mw.IsAdmin(c)
 	if err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_Unauthorized,
@@ -48,6 +49,8 @@ func DeleteRole(c *gin.Context) {
 		return
 	}
 
+	mw.Audit(c, model.AuditTargetRole, "delete", req.Role, nil, nil)
+
 	resp.Code = response.Code_Success
 	resp.Msg = "删除角色成功"
 
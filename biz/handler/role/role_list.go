@@ -2,8 +2,8 @@ package role
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -47,7 +47,7 @@ func RoleList(c *gin.Context) {
 	resp := new(ListResp)
 
 	// 检查是否为管理员
-	err := utils.IsAdmin(c)
+	err := mw.IsAdmin(c)
 	if err != nil {
 		c.JSON(http.StatusOK, &ListResp{
 			Code: response.Code_Unauthorized,
@@ -0,0 +1,91 @@
+package service_account
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KeyData struct {
+	ID           uint   `json:"id"`
+	Name         string `json:"name"`
+	Prefix       string `json:"prefix"`
+	Scope        string `json:"scope"`
+	ExpireTime   string `json:"expire_time"`
+	Expired      bool   `json:"expired"`
+	LastUsedTime string `json:"last_used_time"`
+	LastUsedIp   string `json:"last_used_ip"`
+	CreateTime   string `json:"create_time"`
+}
+
+type KeyListResp struct {
+	Code response.Code `json:"code"`
+	Msg  string        `json:"msg"`
+	Data []*KeyData    `json:"data"`
+}
+
+// ApiKeyList API Key列表
+//
+//	@Tags			服务账号
+//	@Summary		API Key列表
+//	@Description	获取服务账号的 API Key 列表，包括最后使用时间和IP，不返回密钥明文
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			user_id	path		int	true	"服务账号ID"
+//	@Success		200		{object}	KeyListResp
+//	@Security		ApiKeyAuth
+//	@router			/api/service_account/key/list/{user_id} [GET]
+func ApiKeyList(c *gin.Context) {
+	uriReq := new(UserUriReq)
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(KeyListResp)
+
+	if err := mw.IsAdmin(c); err != nil {
+		c.JSON(http.StatusOK, &KeyListResp{
+			Code: response.Code_Unauthorized,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	keys, err := dal.GetApiKeysByUserID(uriReq.UserId)
+	if err != nil {
+		c.JSON(http.StatusOK, &KeyListResp{
+			Code: response.Code_DBErr,
+			Msg:  "获取API Key列表失败: " + err.Error(),
+		})
+		return
+	}
+
+	var keyList []*KeyData
+	for _, k := range keys {
+		data := &KeyData{
+			ID:         k.ID,
+			Name:       k.Name,
+			Prefix:     k.Prefix,
+			Scope:      k.Scope,
+			Expired:    k.IsExpired(),
+			LastUsedIp: k.LastUsedIp,
+			CreateTime: k.CreateTime.Format("2006-01-02 15:04:05"),
+		}
+		if k.ExpireTime != nil {
+			data.ExpireTime = k.ExpireTime.Format("2006-01-02 15:04:05")
+		}
+		if k.LastUsedTime != nil {
+			data.LastUsedTime = k.LastUsedTime.Format("2006-01-02 15:04:05")
+		}
+		keyList = append(keyList, data)
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "获取成功"
+	resp.Data = keyList
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,137 @@
+package service_account
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserUriReq struct {
+	UserId int `uri:"user_id" binding:"required,min=1"`
+}
+
+type CreateKeyReq struct {
+	Name string `json:"name" binding:"required,min=1,max=255"`
+	// 权限范围：r 只读，rw 读写(默认)
+	Scope string `json:"scope" binding:"omitempty,oneof=r rw"`
+	// 有效天数，0 表示永不过期
+	ExpireDays int `json:"expire_days" binding:"omitempty,min=0,max=3650"`
+}
+
+type CreateKeyData struct {
+	ID uint `json:"id"`
+	// API Key 明文，只在创建时返回一次
+	Key        string     `json:"key"`
+	Prefix     string     `json:"prefix"`
+	Scope      string     `json:"scope"`
+	ExpireTime *time.Time `json:"expire_time"`
+}
+
+type CreateKeyResp struct {
+	Code response.Code  `json:"code"`
+	Msg  string         `json:"msg"`
+	Data *CreateKeyData `json:"data"`
+}
+
+// CreateApiKey 创建API Key
+//
+//	@Tags			服务账号
+//	@Summary		创建API Key
+//	@Description	为服务账号创建 API Key，明文只在创建时返回一次。API Key 可作为 Authorization 请求头或 nacos 接口的 accessToken 使用
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			user_id	path		int				true	"服务账号ID"
+//	@Param			req		body		CreateKeyReq	true	"API Key信息"
+//	@Success		200		{object}	CreateKeyResp
+//	@Security		ApiKeyAuth
+//	@router			/api/service_account/key/add/{user_id} [PUT]
+func CreateApiKey(c *gin.Context) {
+	req := new(CreateKeyReq)
+	uriReq := new(UserUriReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Scope == "" {
+		req.Scope = model.ApiKeyScopeReadWrite
+	}
+	resp := new(CreateKeyResp)
+
+	if err := mw.IsAdmin(c); err != nil {
+		c.JSON(http.StatusOK, &CreateKeyResp{
+			Code: response.Code_Unauthorized,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	userData, err := dal.GetUserByID(uriReq.UserId)
+	if err != nil {
+		c.JSON(http.StatusOK, &CreateKeyResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if userData == nil || !userData.IsServiceAccount {
+		c.JSON(http.StatusOK, &CreateKeyResp{
+			Code: response.Code_Err,
+			Msg:  "服务账号不存在",
+		})
+		return
+	}
+
+	key, prefix, err := utils.GenerateApiKey()
+	if err != nil {
+		c.JSON(http.StatusOK, &CreateKeyResp{
+			Code: response.Code_Err,
+			Msg:  "生成API Key失败: " + err.Error(),
+		})
+		return
+	}
+
+	apiKey := &model.ApiKey{
+		UserID:     userData.ID,
+		Name:       req.Name,
+		Prefix:     prefix,
+		KeyHash:    utils.HashApiKey(key),
+		Scope:      req.Scope,
+		CreateTime: time.Now(),
+	}
+	if req.ExpireDays > 0 {
+		expireTime := time.Now().AddDate(0, 0, req.ExpireDays)
+		apiKey.ExpireTime = &expireTime
+	}
+	if err = dal.CreateApiKey(apiKey); err != nil {
+		c.JSON(http.StatusOK, &CreateKeyResp{
+			Code: response.Code_DBErr,
+			Msg:  "创建API Key失败: " + err.Error(),
+		})
+		return
+	}
+
+	// API Key 只记录前缀，密钥哈希不会序列化
+	mw.Audit(c, model.AuditTargetApiKey, "create", apiKey.Prefix, nil, apiKey)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "创建成功，请妥善保存API Key，关闭后无法再次查看"
+	resp.Data = &CreateKeyData{
+		ID:         apiKey.ID,
+		Key:        key,
+		Prefix:     apiKey.Prefix,
+		Scope:      apiKey.Scope,
+		ExpireTime: apiKey.ExpireTime,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,77 @@
+package service_account
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreateReq struct {
+	Username string `json:"username" binding:"required,min=1,max=255"`
+}
+
+// CreateServiceAccount 创建服务账号
+//
+//	@Tags			服务账号
+//	@Summary		创建服务账号
+//	@Description	创建服务账号，服务账号不能通过密码登录，通过 API Key 访问，权限通过角色分配
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			req	body		CreateReq	true	"服务账号信息"
+//	@Success		200	{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/service_account/add [PUT]
+func CreateServiceAccount(c *gin.Context) {
+	req := new(CreateReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	if err := mw.IsAdmin(c); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Unauthorized,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	exist, err := dal.IsUsernameExists(req.Username)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "检查用户名失败: " + err.Error(),
+		})
+		return
+	}
+	if exist {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_AlreadyExists,
+			Msg:  "该用户已存在",
+		})
+		return
+	}
+
+	u := &model.User{
+		Username:         req.Username,
+		Password:         "",
+		Enable:           true,
+		IsServiceAccount: true,
+	}
+	if err = dal.CreateUser([]*model.User{u}); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_DBErr, Msg: "服务账号新建失败: " + err.Error()})
+		return
+	}
+
+	mw.Audit(c, model.AuditTargetServiceAccount, "create", u.Username, nil, nil)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "新建服务账号成功"
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,74 @@
+package service_account
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KeyUriReq struct {
+	KeyId int `uri:"key_id" binding:"required,min=1"`
+}
+
+// DeleteApiKey 吊销API Key
+//
+//	@Tags			服务账号
+//	@Summary		吊销API Key
+//	@Description	吊销API Key，吊销后立即失效
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			key_id	path		int	true	"API Key ID"
+//	@Success		200		{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/service_account/key/delete/{key_id} [DELETE]
+func DeleteApiKey(c *gin.Context) {
+	uriReq := new(KeyUriReq)
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	if err := mw.IsAdmin(c); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Unauthorized,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	apiKey, err := dal.GetApiKeyByID(uriReq.KeyId)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if apiKey == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "API Key不存在",
+		})
+		return
+	}
+
+	if err = dal.DeleteApiKey(uriReq.KeyId); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "吊销API Key失败: " + err.Error(),
+		})
+		return
+	}
+
+	mw.Audit(c, model.AuditTargetApiKey, "delete", apiKey.Prefix, apiKey, nil)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "吊销成功"
+
+	c.JSON(http.StatusOK, resp)
+}
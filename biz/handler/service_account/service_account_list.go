@@ -0,0 +1,87 @@
+package service_account
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListReq struct {
+	Page     int32  `form:"page" binding:"required,min=1,max=1000"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=100"`
+	Username string `form:"username" binding:"omitempty,min=1,max=255"`
+}
+
+type ListData struct {
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+	Enable   bool   `json:"enable"`
+}
+
+type ListResp struct {
+	Code  response.Code `json:"code"`
+	Msg   string        `json:"msg"`
+	Total int64         `json:"total"`
+	Data  []*ListData   `json:"data"`
+}
+
+// ServiceAccountList 服务账号列表
+//
+//	@Tags			服务账号
+//	@Summary		服务账号列表
+//	@Description	服务账号列表
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			page		query		int		false	"页码"	default(1)
+//	@Param			page_size	query		int		false	"每页数量"	default(10)
+//	@Param			username	query		string	false	"用户名"
+//	@Success		200			{object}	ListResp
+//	@Security		ApiKeyAuth
+//	@router			/api/service_account/list [GET]
+func ServiceAccountList(c *gin.Context) {
+	req := new(ListReq)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(ListResp)
+
+	if err := mw.IsAdmin(c); err != nil {
+		c.JSON(http.StatusOK, &ListResp{
+			Code: response.Code_Unauthorized,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+
+	users, total, err := dal.GetServiceAccountList(int(req.PageSize), int(offset), req.Username)
+	if err != nil {
+		c.JSON(http.StatusOK, &ListResp{
+			Code: response.Code_DBErr,
+			Msg:  "获取服务账号列表失败: " + err.Error(),
+		})
+		return
+	}
+
+	var userList []*ListData
+	for _, u := range users {
+		userList = append(userList, &ListData{
+			UserId:   strconv.Itoa(int(u.ID)),
+			Username: u.Username,
+			Enable:   u.Enable,
+		})
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "获取成功"
+	resp.Total = total
+	resp.Data = userList
+
+	c.JSON(http.StatusOK, resp)
+}
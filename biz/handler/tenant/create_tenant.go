@@ -3,8 +3,8 @@ package tenant
 import (
 	"confkeeper/biz/dal"
 	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -36,7 +36,7 @@ func CreateTenant(c *gin.Context) {
 	resp := new(response.CommonResp)
 
 	// 检查是否为管理员
-	err := utils.IsAdmin(c)
+	err := mw.IsAdmin(c)
 	if err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_Unauthorized,
@@ -73,6 +73,8 @@ func CreateTenant(c *gin.Context) {
 		return
 	}
 
+	mw.Audit(c, model.AuditTargetTenant, "create", t.TenantID, nil, t)
+
 	resp.Code = response.Code_Success
 	resp.Msg = "新建命名空间成功"
 
@@ -2,8 +2,9 @@ package tenant
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 	"strconv"
 
@@ -34,7 +35,7 @@ func DeleteTenant(c *gin.Context) {
 	resp := new(response.CommonResp)
 
 	// 检查是否为管理员
-	err := utils.IsAdmin(c)
+	err := mw.IsAdmin(c)
 	if err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_Unauthorized,
@@ -70,6 +71,8 @@ func DeleteTenant(c *gin.Context) {
 		return
 	}
 
+	mw.Audit(c, model.AuditTargetTenant, "delete", tenantInfo.TenantID, tenantInfo, nil)
+
 	resp.Code = response.Code_Success
 	resp.Msg = "删除命名空间成功"
 
@@ -2,8 +2,11 @@ package user
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
 	"confkeeper/utils"
+	"confkeeper/utils/password"
 	"net/http"
 	"strconv"
 
@@ -47,7 +50,7 @@ func ChangePasswd(c *gin.Context) {
 	tokenUserId, _ := utils.GetUseridFromContext(c)
 
 	if userId != tokenUserId {
-		if tokenUserId != 1 {
+		if err := mw.IsAdmin(c); err != nil {
 			c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Unauthorized, Msg: "不能修改别人的密码"})
 			return
 		}
@@ -70,7 +73,19 @@ func ChangePasswd(c *gin.Context) {
 		return
 	}
 
-	userData.Password = utils.MD5(req.Password)
+	if userData.IsServiceAccount {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "服务账号不能设置密码，请使用API Key"})
+		return
+	}
+
+	userData.Password, err = password.Hash(req.Password)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "生成密码哈希失败: " + err.Error(),
+		})
+		return
+	}
 
 	// 方法保存数据
 	err = dal.UpdateUser(userData)
@@ -82,6 +97,17 @@ func ChangePasswd(c *gin.Context) {
 		return
 	}
 
+	// 修改密码后吊销已签发的token
+	if err = utils.RevokeUserTokens(userId); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "密码已修改，但吊销会话失败: " + err.Error(),
+		})
+		return
+	}
+
+	mw.Audit(c, model.AuditTargetUser, "change_password", userData.Username, nil, nil)
+
 	// 返回成功响应
 	resp.Code = response.Code_Success
 	resp.Msg = "密码更新成功"
@@ -3,8 +3,8 @@ package user
 import (
 	"confkeeper/biz/dal"
 	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +12,7 @@ import (
 
 type CreateReq struct {
 	Username string `json:"username" binding:"required,min=1,max=255"`
+	IsAdmin  bool   `json:"is_admin"`
 }
 
 // CreateUser 创建用户
@@ -50,7 +51,7 @@ func CreateUser(c *gin.Context) {
 		return
 	}
 
-	err = utils.IsAdmin(c)
+	err = mw.IsAdmin(c)
 	if err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_Unauthorized,
@@ -63,6 +64,7 @@ func CreateUser(c *gin.Context) {
 		Username: req.Username,
 		Password: "",
 		Enable:   true,
+		IsAdmin:  req.IsAdmin,
 	}
 
 	if err = dal.CreateUser([]*model.User{u}); err != nil {
@@ -70,6 +72,8 @@ func CreateUser(c *gin.Context) {
 		return
 	}
 
+	mw.Audit(c, model.AuditTargetUser, "create", u.Username, nil, newUserAuditSummary(u))
+
 	resp.Code = response.Code_Success
 	resp.Msg = "新建用户成功"
 
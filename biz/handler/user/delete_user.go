@@ -2,6 +2,8 @@ package user
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
 	"confkeeper/utils"
 	"net/http"
@@ -33,7 +35,7 @@ func DeleteUser(c *gin.Context) {
 	}
 	resp := new(response.CommonResp)
 
-	err := utils.IsAdmin(c)
+	err := mw.IsAdmin(c)
 	if err != nil {
 		c.JSON(http.StatusOK, &response.CommonResp{
 			Code: response.Code_Unauthorized,
@@ -44,14 +46,19 @@ func DeleteUser(c *gin.Context) {
 
 	reqUserId, _ := strconv.Atoi(req.UserId)
 
-	if reqUserId == 1 {
-		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "不能删除管理员"})
+	userData, err := dal.GetUserByID(reqUserId)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_DBErr, Msg: "数据库查询错误: " + err.Error()})
+		return
+	}
+	if userData == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_DBErr, Msg: "用户未找到"})
 		return
 	}
 
-	userId, _ := utils.GetUseridFromContext(c)
-	if userId != 1 {
-		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "非管理员账号没有权限"})
+	// 管理员需要先取消管理员权限才能删除
+	if userData.IsAdmin {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "不能删除管理员，请先取消管理员权限"})
 		return
 	}
 
@@ -59,6 +66,12 @@ func DeleteUser(c *gin.Context) {
 		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_DBErr, Msg: "删除用户失败: " + err.Error()})
 		return
 	}
+	if err = utils.RevokeUserTokens(reqUserId); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "用户已删除，但吊销会话失败: " + err.Error()})
+		return
+	}
+	mw.Audit(c, model.AuditTargetUser, "delete", userData.Username, newUserAuditSummary(userData), nil)
+
 	resp.Code = response.Code_Success
 	resp.Msg = "用户" + req.UserId + "删除成功"
 
@@ -0,0 +1,85 @@
+package user
+
+import (
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"confkeeper/utils/config"
+	"confkeeper/utils/ldap_client"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LdapCheckReq struct {
+	// 为空时只检查连接和服务账号绑定
+	Username string `json:"username" binding:"max=255"`
+	// 为空时不校验用户密码
+	Password string `json:"password" binding:"max=255"`
+}
+
+type LdapCheckData struct {
+	// 用户DN，未指定用户名时为空
+	UserDN string `json:"user_dn"`
+	// 用户所在的组
+	Groups []string `json:"groups"`
+	// 按 role_mapping 同步的角色
+	Roles []string `json:"roles"`
+}
+
+type LdapCheckResp struct {
+	Code response.Code  `json:"code"`
+	Msg  string         `json:"msg"`
+	Data *LdapCheckData `json:"data"`
+}
+
+// LdapCheck 检查LDAP配置
+//
+//	@Tags			用户
+//	@Summary		检查LDAP配置
+//	@Description	使用当前配置连接LDAP服务器并绑定服务账号，指定用户名时搜索该用户及其所在的组，同时指定密码时校验用户密码
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			req	body		LdapCheckReq	true	"待检查的用户"
+//	@Success		200	{object}	LdapCheckResp
+//	@Security		ApiKeyAuth
+//	@router			/api/user/ldap/check [POST]
+func LdapCheck(c *gin.Context) {
+	req := new(LdapCheckReq)
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(LdapCheckResp)
+
+	// 检查管理员权限
+	if err := mw.IsAdmin(c); err != nil {
+		c.JSON(http.StatusOK, &LdapCheckResp{
+			Code: response.Code_Unauthorized,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	ldapUser, err := ldap_client.CheckConnection(req.Username, req.Password)
+	if err != nil {
+		c.JSON(http.StatusOK, &LdapCheckResp{
+			Code: response.Code_Err,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	data := new(LdapCheckData)
+	if ldapUser != nil {
+		data.UserDN = ldapUser.DN
+		data.Groups = ldapUser.Groups
+		data.Roles, _ = utils.MappedRoles(config.Cfg.Ldap.RoleMapping, ldapUser.Groups)
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "LDAP连接正常"
+	resp.Data = data
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -2,7 +2,9 @@ package user
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
 	"confkeeper/utils"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -36,16 +38,45 @@ func NacosUserLogin(c *gin.Context) {
 	}
 	resp := new(NacosLoginResp)
 
+	// 检查登录失败锁定
+	if err := dal.CheckLoginLimit(req.Username, c.ClientIP()); err != nil {
+		c.JSON(http.StatusOK, gin.H{"msg": err.Error()})
+		return
+	}
+
 	userData, err := dal.UserLogin(req.Username)
 	if err != nil {
+		dal.RecordLoginFailure(req.Username, c.ClientIP())
 		c.JSON(http.StatusOK, gin.H{"msg": err.Error()})
 		return
 	}
 
-	if userData.Password != utils.MD5(req.Password) {
-		c.JSON(http.StatusOK, gin.H{"msg": "密码错误"})
+	if !userData.Enable {
+		c.JSON(http.StatusOK, gin.H{"msg": "用户已禁用"})
+		return
+	}
+
+	// 服务账号以 API Key 作为密码，校验通过后直接把 API Key 作为 accessToken 返回
+	if userData.IsServiceAccount {
+		if !utils.IsApiKey(req.Password) || mw.AuthenticateApiKey(c, req.Password) != nil || c.GetString("username") != userData.Username {
+			dal.RecordLoginFailure(req.Username, c.ClientIP())
+			c.JSON(http.StatusOK, gin.H{"msg": "API Key错误"})
+			return
+		}
+		dal.RecordLoginSuccess(userData)
+		resp.AccessToken = req.Password
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	if err := mw.VerifyPassword(c, userData, req.Password); err != nil {
+		if !errors.Is(err, mw.ErrUserSync) {
+			dal.RecordLoginFailure(req.Username, c.ClientIP())
+		}
+		c.JSON(http.StatusOK, gin.H{"msg": err.Error()})
 		return
 	}
+	dal.RecordLoginSuccess(userData)
 
 	var token string
 	token, _ = utils.GenerateToken(userData.ID, req.Username, 1)
@@ -0,0 +1,175 @@
+package user
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"confkeeper/utils/config"
+	"confkeeper/utils/oidc_client"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OidcCallbackReq struct {
+	Code             string `form:"code" binding:"omitempty"`
+	State            string `form:"state" binding:"omitempty"`
+	Error            string `form:"error" binding:"omitempty"`
+	ErrorDescription string `form:"error_description" binding:"omitempty"`
+}
+
+// OidcCallback OIDC登录回调
+//
+//	@Tags			用户
+//	@Summary		OIDC登录回调
+//	@Description	身份提供方登录完成后的回调，校验 ID Token 后自动创建用户、按用户组同步角色并签发token。配置了 success_url 时通过 URL fragment 跳转回前端，否则直接返回 JSON
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			code	query		string	true	"授权码"
+//	@Param			state	query		string	true	"state"
+//	@Success		200		{object}	LoginResp
+//	@router			/api/user/oidc/callback [GET]
+func OidcCallback(c *gin.Context) {
+	req := new(OidcCallbackReq)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !config.Cfg.Oidc.Enabled {
+		oidcLoginFailed(c, response.Code_Err, "未启用OIDC登录")
+		return
+	}
+
+	// 登录状态只能使用一次
+	cookie, _ := c.Cookie(oidcStateCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, "", -1, oidcStateCookiePath, "", oidcSecureCookie(c), true)
+
+	if req.Error != "" {
+		oidcLoginFailed(c, response.Code_Unauthorized, "身份提供方登录失败: "+req.Error+" "+req.ErrorDescription)
+		return
+	}
+	if req.Code == "" {
+		oidcLoginFailed(c, response.Code_Unauthorized, "缺少授权码")
+		return
+	}
+
+	state, err := oidc_client.ParseLoginState(cookie, req.State)
+	if err != nil {
+		oidcLoginFailed(c, response.Code_Unauthorized, err.Error())
+		return
+	}
+
+	claims, err := oidc_client.Exchange(req.Code, state)
+	if err != nil {
+		oidcLoginFailed(c, response.Code_Unauthorized, err.Error())
+		return
+	}
+
+	usernameClaim := config.Cfg.Oidc.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = "preferred_username"
+	}
+	username := oidc_client.StringClaim(claims, usernameClaim)
+	if username == "" {
+		oidcLoginFailed(c, response.Code_Unauthorized, "ID Token 缺少用户名 claim: "+usernameClaim)
+		return
+	}
+
+	// 按 iss + sub 查找绑定的用户，用户名可以在身份提供方修改，不能用来匹配已有用户
+	issuer := oidc_client.StringClaim(claims, "iss")
+	subject := oidc_client.StringClaim(claims, "sub")
+	if issuer == "" || subject == "" {
+		oidcLoginFailed(c, response.Code_Unauthorized, "ID Token 缺少 iss 或 sub")
+		return
+	}
+	userData, err := dal.GetUserByOidcIdentity(issuer, subject)
+	if err != nil {
+		oidcLoginFailed(c, response.Code_DBErr, "数据库查询错误: "+err.Error())
+		return
+	}
+	if userData == nil {
+		// 用户名已属于本地、LDAP 或其他 OIDC 身份的用户时拒绝登录，避免通过修改用户名接管账号
+		exist, err := dal.IsUsernameExists(username)
+		if err != nil {
+			oidcLoginFailed(c, response.Code_DBErr, "数据库查询错误: "+err.Error())
+			return
+		}
+		if exist {
+			oidcLoginFailed(c, response.Code_Unauthorized, "用户名 "+username+" 已被其他账号使用，不能通过单点登录登录")
+			return
+		}
+
+		// 与 LDAP 登录一样自动创建用户，密码为空，只能通过单点登录登录
+		userData = &model.User{
+			Username:    username,
+			Enable:      true,
+			Source:      model.UserSourceOidc,
+			OidcIssuer:  issuer,
+			OidcSubject: subject,
+		}
+		if createErr := dal.CreateUser([]*model.User{userData}); createErr != nil {
+			oidcLoginFailed(c, response.Code_DBErr, "用户同步失败: "+createErr.Error())
+			return
+		}
+		c.Set("username", userData.Username)
+		mw.Audit(c, model.AuditTargetUser, "create", userData.Username, nil, newUserAuditSummary(userData))
+	}
+	if userData.IsServiceAccount {
+		oidcLoginFailed(c, response.Code_Unauthorized, "服务账号不能登录，请使用API Key")
+		return
+	}
+	if !userData.Enable {
+		oidcLoginFailed(c, response.Code_Unauthorized, "用户已禁用")
+		return
+	}
+
+	// 按用户组同步角色，只处理 role_mapping 中配置的角色
+	groupsClaim := config.Cfg.Oidc.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = "groups"
+	}
+	roles, managedRoles := utils.MappedRoles(config.Cfg.Oidc.RoleMapping, oidc_client.GroupsClaim(claims, groupsClaim))
+	if err := mw.SyncExternalRoles(c, userData.Username, roles, managedRoles); err != nil {
+		oidcLoginFailed(c, response.Code_DBErr, "角色同步失败: "+err.Error())
+		return
+	}
+
+	// 两步验证由身份提供方负责，单点登录不再要求本地验证码
+	data, err := issueLoginTokens(userData, state.RememberMe)
+	if err != nil {
+		oidcLoginFailed(c, response.Code_Err, "生成token失败: "+err.Error())
+		return
+	}
+
+	if config.Cfg.Oidc.SuccessURL != "" {
+		fragment := url.Values{}
+		fragment.Set("token", data.Token)
+		fragment.Set("refresh_token", data.RefreshToken)
+		fragment.Set("expires_in", strconv.FormatInt(data.ExpiresIn, 10))
+		c.Redirect(http.StatusFound, config.Cfg.Oidc.SuccessURL+"#"+fragment.Encode())
+		return
+	}
+
+	c.JSON(http.StatusOK, &LoginResp{
+		Code: response.Code_Success,
+		Msg:  "登录成功",
+		Data: data,
+	})
+}
+
+// oidcLoginFailed 配置了 success_url 时带上错误信息跳转回前端，否则返回 JSON
+func oidcLoginFailed(c *gin.Context, code response.Code, msg string) {
+	if config.Cfg.Oidc.SuccessURL != "" {
+		fragment := url.Values{}
+		fragment.Set("error", msg)
+		c.Redirect(http.StatusFound, config.Cfg.Oidc.SuccessURL+"#"+fragment.Encode())
+		return
+	}
+	c.JSON(http.StatusOK, &LoginResp{Code: code, Msg: msg})
+}
@@ -0,0 +1,70 @@
+package user
+
+import (
+	"confkeeper/biz/response"
+	"confkeeper/utils/config"
+	"confkeeper/utils/oidc_client"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oidcStateCookie 保存授权码登录临时状态的 cookie
+const (
+	oidcStateCookie     = "confkeeper_oidc_state"
+	oidcStateCookiePath = "/api/user/oidc"
+)
+
+type OidcLoginReq struct {
+	RememberMe bool `form:"remember_me" binding:"omitempty"`
+}
+
+// OidcLogin OIDC单点登录
+//
+//	@Tags			用户
+//	@Summary		OIDC单点登录
+//	@Description	跳转到身份提供方登录，使用授权码 + PKCE 流程，登录完成后回调 /api/user/oidc/callback
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			remember_me	query		bool	false	"记住我"
+//	@Success		302			{string}	string	"跳转到身份提供方"
+//	@router			/api/user/oidc/login [GET]
+func OidcLogin(c *gin.Context) {
+	req := new(OidcLoginReq)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !config.Cfg.Oidc.Enabled {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "未启用OIDC登录"})
+		return
+	}
+
+	state, err := oidc_client.NewLoginState(req.RememberMe)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "生成登录状态失败: " + err.Error()})
+		return
+	}
+	authURL, err := oidc_client.AuthCodeURL(state)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: err.Error()})
+		return
+	}
+	cookie, err := state.Encode()
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "生成登录状态失败: " + err.Error()})
+		return
+	}
+
+	// 回调是身份提供方发起的跨站跳转，SameSite 需要为 Lax 才能带上 cookie
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, cookie, state.MaxAge(), oidcStateCookiePath, "", oidcSecureCookie(c), true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// oidcSecureCookie 通过 https 访问时只允许 https 携带 cookie
+func oidcSecureCookie(c *gin.Context) bool {
+	return c.Request.TLS != nil || strings.HasPrefix(config.Cfg.Oidc.RedirectURL, "https://")
+}
@@ -0,0 +1,47 @@
+package user
+
+import (
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refresh_token" binding:"required,min=1"`
+}
+
+// RefreshToken 刷新token
+//
+//	@Tags			用户
+//	@Summary		刷新token
+//	@Description	使用refresh token换取新的token和refresh token，旧的refresh token随即失效，重复使用会吊销整个会话
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			req	body		RefreshTokenReq	true	"refresh token"
+//	@Success		200	{object}	LoginResp
+//	@router			/api/user/refresh [POST]
+func RefreshToken(c *gin.Context) {
+	req := new(RefreshTokenReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(LoginResp)
+
+	pair, err := utils.RefreshTokenPair(req.RefreshToken)
+	if err != nil {
+		c.JSON(http.StatusOK, &LoginResp{Code: response.Code_Unauthorized, Msg: err.Error()})
+		return
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "刷新成功"
+	resp.Data = &LoginData{
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    pair.ExpiresIn,
+	}
+	c.JSON(http.StatusOK, resp)
+}
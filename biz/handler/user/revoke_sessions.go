@@ -0,0 +1,78 @@
+package user
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RevokeSessionsReq struct {
+	UserId string `uri:"user_id" binding:"required"`
+}
+
+// RevokeSessions 吊销用户所有会话
+//
+//	@Tags			用户
+//	@Summary		吊销用户所有会话
+//	@Description	吊销用户已签发的所有token，用户需要重新登录。非管理员只能吊销自己的会话
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			user_id	path		string	true	"用户ID"
+//	@Success		200		{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/user/revoke/{user_id} [POST]
+func RevokeSessions(c *gin.Context) {
+	uriReq := new(RevokeSessionsReq)
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	userId, _ := strconv.Atoi(uriReq.UserId)
+	tokenUserId, _ := utils.GetUseridFromContext(c)
+
+	if userId != tokenUserId {
+		if err := mw.IsAdmin(c); err != nil {
+			c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Unauthorized, Msg: "不能吊销别人的会话"})
+			return
+		}
+	}
+
+	userData, err := dal.GetUserByID(userId)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if userData == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "用户未找到",
+		})
+		return
+	}
+
+	if err = utils.RevokeUserTokens(userId); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "吊销会话失败: " + err.Error(),
+		})
+		return
+	}
+
+	mw.Audit(c, model.AuditTargetUser, "revoke_sessions", userData.Username, nil, nil)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "吊销会话成功"
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,111 @@
+package user
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TotpDisableReq struct {
+	UserId string `uri:"user_id" binding:"required"`
+}
+
+type TotpDisableBody struct {
+	// 停用自己的两步验证时需要提供验证码或恢复码
+	Code string `json:"code" binding:"omitempty,max=20"`
+}
+
+// TotpDisable 停用两步验证
+//
+//	@Tags			用户
+//	@Summary		停用两步验证
+//	@Description	停用自己的两步验证需要提供验证码或恢复码；管理员可以直接停用其他用户的两步验证(如用户丢失设备)
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			user_id	path		string			true	"用户ID"
+//	@Param			req		body		TotpDisableBody	false	"验证码"
+//	@Success		200		{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/user/totp/disable/{user_id} [POST]
+func TotpDisable(c *gin.Context) {
+	uriReq := new(TotpDisableReq)
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	// 管理员停用其他用户的两步验证时可以不传请求体
+	req := new(TotpDisableBody)
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBind(req); err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	resp := new(response.CommonResp)
+
+	userId, _ := strconv.Atoi(uriReq.UserId)
+	tokenUserId, _ := utils.GetUseridFromContext(c)
+
+	if userId != tokenUserId {
+		if err := mw.IsAdmin(c); err != nil {
+			c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Unauthorized, Msg: "不能停用别人的两步验证"})
+			return
+		}
+	}
+
+	userData, err := dal.GetUserByID(userId)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if userData == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "用户未找到",
+		})
+		return
+	}
+
+	// 停用自己已启用的两步验证需要校验验证码，防止 token 泄露后被关闭
+	if userId == tokenUserId && userData.TotpEnabled {
+		// 验证码只有6位，同样受登录失败锁定限制，防止 token 泄露后被暴力猜测
+		if err := dal.CheckLoginLimit(userData.Username, c.ClientIP()); err != nil {
+			c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_PasswordErr, Msg: err.Error()})
+			return
+		}
+		ok, err := dal.VerifyTotpCode(userData, req.Code)
+		if err != nil {
+			c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_DBErr, Msg: "数据库查询错误: " + err.Error()})
+			return
+		}
+		if !ok {
+			dal.RecordLoginFailure(userData.Username, c.ClientIP())
+			c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_PasswordErr, Msg: errInvalidTotpCode.Error()})
+			return
+		}
+	}
+
+	if err = dal.DisableTotp(userData); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "停用两步验证失败: " + err.Error(),
+		})
+		return
+	}
+
+	mw.Audit(c, model.AuditTargetUser, "totp_disable", userData.Username, nil, nil)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "两步验证已停用"
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,86 @@
+package user
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"confkeeper/utils/config"
+	"confkeeper/utils/totp"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TotpEnrollData struct {
+	// base32 编码的密钥，无法扫码时手动输入
+	Secret string `json:"secret"`
+	// 验证器应用扫码使用的地址
+	Url string `json:"url"`
+}
+
+type TotpEnrollResp struct {
+	Code response.Code   `json:"code"`
+	Msg  string          `json:"msg"`
+	Data *TotpEnrollData `json:"data"`
+}
+
+// TotpEnroll 绑定两步验证
+//
+//	@Tags			用户
+//	@Summary		绑定两步验证
+//	@Description	生成TOTP密钥，使用验证器应用扫码后调用 /api/user/totp/verify 校验验证码完成绑定
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Success		200	{object}	TotpEnrollResp
+//	@Security		ApiKeyAuth
+//	@router			/api/user/totp/enroll [POST]
+func TotpEnroll(c *gin.Context) {
+	resp := new(TotpEnrollResp)
+
+	userId, _ := utils.GetUseridFromContext(c)
+	userData, err := dal.GetUserByID(userId)
+	if err != nil || userData == nil {
+		c.JSON(http.StatusOK, &TotpEnrollResp{Code: response.Code_DBErr, Msg: "用户未找到"})
+		return
+	}
+
+	data, err := enrollTotp(userData)
+	if err != nil {
+		c.JSON(http.StatusOK, &TotpEnrollResp{Code: response.Code_Err, Msg: err.Error()})
+		return
+	}
+
+	mw.Audit(c, model.AuditTargetUser, "totp_enroll", userData.Username, nil, nil)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "请使用验证器应用扫码并输入验证码完成绑定"
+	resp.Data = data
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// enrollTotp 为尚未启用两步验证的用户生成新的密钥
+func enrollTotp(userData *model.User) (*TotpEnrollData, error) {
+	if userData.IsServiceAccount {
+		return nil, errors.New("服务账号不支持两步验证")
+	}
+	if userData.TotpEnabled {
+		return nil, errors.New("已启用两步验证，如需重新绑定请先停用")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.New("生成密钥失败: " + err.Error())
+	}
+	if err := dal.SaveTotpSecret(userData, secret); err != nil {
+		return nil, errors.New("保存密钥失败: " + err.Error())
+	}
+
+	return &TotpEnrollData{
+		Secret: secret,
+		Url:    totp.URL(config.Cfg.Server.Name, userData.Username, secret),
+	}, nil
+}
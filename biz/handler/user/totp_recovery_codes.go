@@ -0,0 +1,78 @@
+package user
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"confkeeper/utils/totp"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TotpRecoveryCodes 重新生成恢复码
+//
+//	@Tags			用户
+//	@Summary		重新生成恢复码
+//	@Description	校验验证码后重新生成恢复码，旧的恢复码全部失效，新的恢复码只返回一次
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			req	body		TotpCodeReq	true	"验证码"
+//	@Success		200	{object}	TotpRecoveryCodesResp
+//	@Security		ApiKeyAuth
+//	@router			/api/user/totp/recovery_codes [POST]
+func TotpRecoveryCodes(c *gin.Context) {
+	req := new(TotpCodeReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(TotpRecoveryCodesResp)
+
+	userId, _ := utils.GetUseridFromContext(c)
+	userData, err := dal.GetUserByID(userId)
+	if err != nil || userData == nil {
+		c.JSON(http.StatusOK, &TotpRecoveryCodesResp{Code: response.Code_DBErr, Msg: "用户未找到"})
+		return
+	}
+	if !userData.TotpEnabled {
+		c.JSON(http.StatusOK, &TotpRecoveryCodesResp{Code: response.Code_Err, Msg: "未启用两步验证"})
+		return
+	}
+
+	// 验证码只有6位，同样受登录失败锁定限制，防止 token 泄露后被暴力猜测
+	if err := dal.CheckLoginLimit(userData.Username, c.ClientIP()); err != nil {
+		c.JSON(http.StatusOK, &TotpRecoveryCodesResp{Code: response.Code_PasswordErr, Msg: err.Error()})
+		return
+	}
+	ok, err := dal.VerifyTotpCode(userData, req.Code)
+	if err != nil {
+		c.JSON(http.StatusOK, &TotpRecoveryCodesResp{Code: response.Code_DBErr, Msg: "数据库查询错误: " + err.Error()})
+		return
+	}
+	if !ok {
+		dal.RecordLoginFailure(userData.Username, c.ClientIP())
+		c.JSON(http.StatusOK, &TotpRecoveryCodesResp{Code: response.Code_PasswordErr, Msg: errInvalidTotpCode.Error()})
+		return
+	}
+
+	recoveryCodes, err := totp.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		c.JSON(http.StatusOK, &TotpRecoveryCodesResp{Code: response.Code_Err, Msg: "生成恢复码失败: " + err.Error()})
+		return
+	}
+	if err := dal.ReplaceRecoveryCodes(userData.ID, recoveryCodes); err != nil {
+		c.JSON(http.StatusOK, &TotpRecoveryCodesResp{Code: response.Code_DBErr, Msg: "保存恢复码失败: " + err.Error()})
+		return
+	}
+
+	mw.Audit(c, model.AuditTargetUser, "totp_recovery_codes", userData.Username, nil, nil)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "恢复码已重新生成，请妥善保存"
+	resp.Data = recoveryCodes
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,59 @@
+package user
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TotpRequireReq struct {
+	Required *bool `json:"required" binding:"required"`
+}
+
+// TotpRequire 设置是否要求所有用户启用两步验证
+//
+//	@Tags			用户
+//	@Summary		要求启用两步验证
+//	@Description	开启后所有用户登录控制台时都需要两步验证，未绑定的用户在登录时完成绑定。API Key 和 Nacos 登录不受影响
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			req	body		TotpRequireReq	true	"是否要求"
+//	@Success		200	{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/user/totp/require [POST]
+func TotpRequire(c *gin.Context) {
+	req := new(TotpRequireReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	// 检查管理员权限
+	if err := mw.IsAdmin(c); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Unauthorized,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	if err := dal.SetTotpRequired(*req.Required); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "保存设置失败: " + err.Error(),
+		})
+		return
+	}
+
+	mw.Audit(c, model.AuditTargetSetting, "update", "totp_required", nil, map[string]bool{"required": *req.Required})
+
+	resp.Code = response.Code_Success
+	resp.Msg = "设置成功"
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,69 @@
+package user
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TotpStatusData struct {
+	// 当前用户是否已启用两步验证
+	Enabled bool `json:"enabled"`
+	// 管理员是否要求所有用户启用两步验证
+	Required bool `json:"required"`
+	// 剩余可用的恢复码数量
+	RecoveryCodesLeft int64 `json:"recovery_codes_left"`
+}
+
+type TotpStatusResp struct {
+	Code response.Code   `json:"code"`
+	Msg  string          `json:"msg"`
+	Data *TotpStatusData `json:"data"`
+}
+
+// TotpStatus 两步验证状态
+//
+//	@Tags			用户
+//	@Summary		两步验证状态
+//	@Description	获取当前用户的两步验证状态
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Success		200	{object}	TotpStatusResp
+//	@Security		ApiKeyAuth
+//	@router			/api/user/totp/status [GET]
+func TotpStatus(c *gin.Context) {
+	resp := new(TotpStatusResp)
+
+	userId, _ := utils.GetUseridFromContext(c)
+	userData, err := dal.GetUserByID(userId)
+	if err != nil || userData == nil {
+		c.JSON(http.StatusOK, &TotpStatusResp{Code: response.Code_DBErr, Msg: "用户未找到"})
+		return
+	}
+
+	required, err := dal.IsTotpRequired()
+	if err != nil {
+		c.JSON(http.StatusOK, &TotpStatusResp{Code: response.Code_DBErr, Msg: "数据库查询错误: " + err.Error()})
+		return
+	}
+
+	data := &TotpStatusData{
+		Enabled:  userData.TotpEnabled,
+		Required: required,
+	}
+	if userData.TotpEnabled {
+		if data.RecoveryCodesLeft, err = dal.CountRecoveryCodes(userData.ID); err != nil {
+			c.JSON(http.StatusOK, &TotpStatusResp{Code: response.Code_DBErr, Msg: "数据库查询错误: " + err.Error()})
+			return
+		}
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "获取成功"
+	resp.Data = data
+
+	c.JSON(http.StatusOK, resp)
+}
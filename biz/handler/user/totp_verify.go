@@ -0,0 +1,95 @@
+package user
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"confkeeper/utils/totp"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 每次生成的恢复码数量
+const recoveryCodeCount = 10
+
+var errInvalidTotpCode = errors.New("验证码错误")
+
+type TotpCodeReq struct {
+	Code string `json:"code" binding:"required,min=1,max=20"`
+}
+
+type TotpRecoveryCodesResp struct {
+	Code response.Code `json:"code"`
+	Msg  string        `json:"msg"`
+	Data []string      `json:"data"`
+}
+
+// TotpVerify 校验验证码完成两步验证绑定
+//
+//	@Tags			用户
+//	@Summary		完成两步验证绑定
+//	@Description	校验验证器应用生成的验证码，通过后启用两步验证并返回恢复码，恢复码只返回一次
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			req	body		TotpCodeReq	true	"验证码"
+//	@Success		200	{object}	TotpRecoveryCodesResp
+//	@Security		ApiKeyAuth
+//	@router			/api/user/totp/verify [POST]
+func TotpVerify(c *gin.Context) {
+	req := new(TotpCodeReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(TotpRecoveryCodesResp)
+
+	userId, _ := utils.GetUseridFromContext(c)
+	userData, err := dal.GetUserByID(userId)
+	if err != nil || userData == nil {
+		c.JSON(http.StatusOK, &TotpRecoveryCodesResp{Code: response.Code_DBErr, Msg: "用户未找到"})
+		return
+	}
+
+	recoveryCodes, err := activateTotp(userData, req.Code)
+	if err != nil {
+		c.JSON(http.StatusOK, &TotpRecoveryCodesResp{Code: response.Code_PasswordErr, Msg: err.Error()})
+		return
+	}
+
+	mw.Audit(c, model.AuditTargetUser, "totp_enable", userData.Username, nil, nil)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "两步验证已启用，请妥善保存恢复码"
+	resp.Data = recoveryCodes
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// activateTotp 校验绑定中的密钥生成的验证码，通过后启用两步验证并返回恢复码
+func activateTotp(userData *model.User, code string) ([]string, error) {
+	if userData.TotpEnabled {
+		return nil, errors.New("已启用两步验证")
+	}
+	if userData.TotpSecret == "" {
+		return nil, errors.New("请先获取两步验证密钥")
+	}
+
+	step, ok := totp.Validate(userData.TotpSecret, code, time.Now())
+	if !ok {
+		return nil, errInvalidTotpCode
+	}
+
+	recoveryCodes, err := totp.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, errors.New("生成恢复码失败: " + err.Error())
+	}
+	if err := dal.EnableTotp(userData, step, recoveryCodes); err != nil {
+		return nil, errors.New("启用两步验证失败: " + err.Error())
+	}
+	return recoveryCodes, nil
+}
@@ -0,0 +1,77 @@
+package user
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UnlockUserReq struct {
+	UserId string `uri:"user_id" binding:"required"`
+}
+
+// UnlockUser 解除用户锁定
+//
+//	@Tags			用户
+//	@Summary		解除用户锁定
+//	@Description	解除用户因登录失败次数过多导致的锁定，并清零连续失败次数
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			user_id	path		string	true	"用户ID"
+//	@Success		200		{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/user/unlock/{user_id} [POST]
+func UnlockUser(c *gin.Context) {
+	uriReq := new(UnlockUserReq)
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	// 检查管理员权限
+	if err := mw.IsAdmin(c); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Unauthorized,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	userId, _ := strconv.Atoi(uriReq.UserId)
+	userData, err := dal.GetUserByID(userId)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if userData == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "用户未找到",
+		})
+		return
+	}
+
+	if err = dal.UnlockUser(userData); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "解除锁定失败: " + err.Error(),
+		})
+		return
+	}
+
+	mw.Audit(c, model.AuditTargetUser, "unlock", userData.Username, nil, nil)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "解除锁定成功"
+
+	c.JSON(http.StatusOK, resp)
+}
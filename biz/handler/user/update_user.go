@@ -2,8 +2,11 @@ package user
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
 	"confkeeper/utils"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -13,6 +16,10 @@ import (
 type UpdateReq struct {
 	Username *string `json:"username" binding:"omitempty,min=1,max=255"`
 	Enable   *bool   `json:"enable" binding:"omitempty"`
+	// 授予或取消管理员权限，仅管理员可修改
+	IsAdmin *bool `json:"is_admin" binding:"omitempty"`
+	// 修改用户来源，仅管理员可修改，用于把早期版本由 LDAP 登录创建的用户改为 LDAP 用户，或改回本地用户
+	Source *string `json:"source" binding:"omitempty,oneof=local ldap"`
 }
 
 type UpdateUriReq struct {
@@ -48,7 +55,7 @@ func UpdateUser(c *gin.Context) {
 	tokenUserId, _ := utils.GetUseridFromContext(c)
 
 	if userId != tokenUserId {
-		if tokenUserId != 1 {
+		if err := mw.IsAdmin(c); err != nil {
 			c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Unauthorized, Msg: "不能修改别人的信息"})
 			return
 		}
@@ -70,6 +77,9 @@ func UpdateUser(c *gin.Context) {
 		})
 		return
 	}
+	// 禁用或改名后吊销已签发的token，token中携带的用户名不再有效
+	oldUsername, oldEnable := userData.Username, userData.Enable
+	before := newUserAuditSummary(userData)
 
 	// 更新用户名等其他字段
 	if req.Username != nil {
@@ -96,8 +106,36 @@ func UpdateUser(c *gin.Context) {
 		userData.Enable = *req.Enable
 	}
 
-	// 方法保存数据
+	if req.IsAdmin != nil && *req.IsAdmin != userData.IsAdmin {
+		if err := mw.IsAdmin(c); err != nil {
+			c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Unauthorized, Msg: "只有管理员可以修改管理员权限"})
+			return
+		}
+		if *req.IsAdmin && userData.IsServiceAccount {
+			c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "服务账号不能设为管理员"})
+			return
+		}
+		userData.IsAdmin = *req.IsAdmin
+	}
+
+	if req.Source != nil && *req.Source != userData.Source {
+		if err := mw.IsAdmin(c); err != nil {
+			c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Unauthorized, Msg: "只有管理员可以修改用户来源"})
+			return
+		}
+		if userData.IsServiceAccount || userData.Source == model.UserSourceOidc {
+			c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "服务账号和单点登录用户不能修改用户来源"})
+			return
+		}
+		userData.Source = *req.Source
+	}
+
+	// 方法保存数据，取消权限或禁用时至少保留一个启用的管理员
 	err = dal.UpdateUser(userData)
+	if errors.Is(err, dal.ErrLastAdmin) {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "不能取消或禁用最后一个启用的管理员"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, &response.CommonResp{
 			Code: response.Code_DBErr,
@@ -106,6 +144,18 @@ func UpdateUser(c *gin.Context) {
 		return
 	}
 
+	if (oldEnable && !userData.Enable) || oldUsername != userData.Username {
+		if err = utils.RevokeUserTokens(userId); err != nil {
+			c.JSON(http.StatusOK, &response.CommonResp{
+				Code: response.Code_Err,
+				Msg:  "用户信息已更新，但吊销会话失败: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	mw.Audit(c, model.AuditTargetUser, "update", oldUsername, before, newUserAuditSummary(userData))
+
 	// 返回成功响应
 	resp.Code = response.Code_Success
 	resp.Msg = "用户信息更新成功"
@@ -0,0 +1,24 @@
+package user
+
+import "confkeeper/biz/model"
+
+// userAuditSummary 用户的审计摘要，不记录密码和两步验证密钥
+type userAuditSummary struct {
+	Username         string `json:"username"`
+	Enable           bool   `json:"enable"`
+	IsAdmin          bool   `json:"is_admin"`
+	IsServiceAccount bool   `json:"is_service_account"`
+	Source           string `json:"source"`
+	TotpEnabled      bool   `json:"totp_enabled"`
+}
+
+func newUserAuditSummary(u *model.User) *userAuditSummary {
+	return &userAuditSummary{
+		Username:         u.Username,
+		Enable:           u.Enable,
+		IsAdmin:          u.IsAdmin,
+		IsServiceAccount: u.IsServiceAccount,
+		Source:           u.Source,
+		TotpEnabled:      u.TotpEnabled,
+	}
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
 	"confkeeper/utils"
 	"net/http"
@@ -15,9 +16,11 @@ type InfoReq struct {
 }
 
 type InfoData struct {
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
-	Enable   bool   `json:"enable"`
+	UserId           string `json:"user_id"`
+	Username         string `json:"username"`
+	Enable           bool   `json:"enable"`
+	IsAdmin          bool   `json:"is_admin"`
+	IsServiceAccount bool   `json:"is_service_account"`
 }
 
 type InfoResp struct {
@@ -50,8 +53,10 @@ func UserInfo(c *gin.Context) {
 	tokenUserId, _ := utils.GetUseridFromContext(c)
 
 	if userId != tokenUserId {
-		c.JSON(http.StatusOK, &InfoResp{Code: response.Code_Unauthorized, Msg: "不能修改获取别人"})
-		return
+		if err := mw.IsAdmin(c); err != nil {
+			c.JSON(http.StatusOK, &InfoResp{Code: response.Code_Unauthorized, Msg: "不能修改获取别人"})
+			return
+		}
 	}
 
 	// 获取用户信息
@@ -74,9 +79,11 @@ func UserInfo(c *gin.Context) {
 	resp.Code = response.Code_Success
 	resp.Msg = "用户信息更新成功"
 	resp.Data = &InfoData{
-		UserId:   strconv.Itoa(int(userData.ID)),
-		Username: userData.Username,
-		Enable:   userData.Enable,
+		UserId:           strconv.Itoa(int(userData.ID)),
+		Username:         userData.Username,
+		Enable:           userData.Enable,
+		IsAdmin:          userData.IsAdmin,
+		IsServiceAccount: userData.IsServiceAccount,
 	}
 
 	c.JSON(http.StatusOK, resp)
@@ -2,10 +2,11 @@ package user
 
 import (
 	"confkeeper/biz/dal"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
-	"confkeeper/utils"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,9 +18,21 @@ type ListReq struct {
 }
 
 type ListData struct {
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
-	Enable   bool   `json:"enable"`
+	UserId           string `json:"user_id"`
+	Username         string `json:"username"`
+	Enable           bool   `json:"enable"`
+	IsAdmin          bool   `json:"is_admin"`
+	IsServiceAccount bool   `json:"is_service_account"`
+	// 用户来源: local / ldap / oidc
+	Source string `json:"source"`
+	// 是否已启用两步验证
+	TotpEnabled bool `json:"totp_enabled"`
+	// 连续登录失败次数
+	FailedLoginCount int `json:"failed_login_count"`
+	// 是否因登录失败次数过多被锁定
+	Locked bool `json:"locked"`
+	// 锁定截止时间，未锁定时为空
+	LockedUntil *time.Time `json:"locked_until"`
 }
 
 type ListResp struct {
@@ -52,7 +65,7 @@ func UserList(c *gin.Context) {
 	resp := new(ListResp)
 
 	// 检查管理员权限
-	err := utils.IsAdmin(c)
+	err := mw.IsAdmin(c)
 	if err != nil {
 		c.JSON(http.StatusOK, &ListResp{
 			Code: response.Code_Unauthorized,
@@ -85,11 +98,21 @@ func UserList(c *gin.Context) {
 	// 转换响应格式
 	var userList []*ListData
 	for _, u := range users {
-		userList = append(userList, &ListData{
-			UserId:   strconv.Itoa(int(u.ID)),
-			Username: u.Username,
-			Enable:   u.Enable,
-		})
+		item := &ListData{
+			UserId:           strconv.Itoa(int(u.ID)),
+			Username:         u.Username,
+			Enable:           u.Enable,
+			IsAdmin:          u.IsAdmin,
+			IsServiceAccount: u.IsServiceAccount,
+			Source:           u.Source,
+			TotpEnabled:      u.TotpEnabled,
+			FailedLoginCount: u.FailedLoginCount,
+			Locked:           u.IsLocked(),
+		}
+		if item.Locked {
+			item.LockedUntil = u.LockedUntil
+		}
+		userList = append(userList, item)
 	}
 
 	resp.Code = response.Code_Success
@@ -3,12 +3,16 @@ package user
 import (
 	"confkeeper/biz/dal"
 	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
 	"confkeeper/biz/response"
 	"confkeeper/utils"
 	"confkeeper/utils/captcha"
 	"confkeeper/utils/config"
 	"confkeeper/utils/ldap_client"
+	"confkeeper/utils/password"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,7 +26,16 @@ type LoginReq struct {
 }
 
 type LoginData struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	// token 有效期(秒)，过期前使用 refresh_token 换取新的 token
+	ExpiresIn int64 `json:"expires_in"`
+	// 需要两步验证时返回，使用该令牌调用 /api/user/login/totp 完成登录
+	MfaToken string `json:"mfa_token,omitempty"`
+	// 管理员要求启用两步验证但用户尚未绑定，需要先调用 /api/user/login/totp/enroll
+	TotpEnrollRequired bool `json:"totp_enroll_required,omitempty"`
+	// 登录时完成绑定后返回的恢复码，只返回一次
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
 }
 
 type LoginResp struct {
@@ -47,7 +60,6 @@ func UserLogin(c *gin.Context) {
 		c.String(http.StatusBadRequest, err.Error())
 		return
 	}
-	resp := new(LoginResp)
 
 	// 验证验证码
 	if !captcha.Store.Verify(req.CaptchaID, req.Captcha, true) {
@@ -58,25 +70,44 @@ func UserLogin(c *gin.Context) {
 		return
 	}
 
+	// 检查登录失败锁定
+	if err := dal.CheckLoginLimit(req.Username, c.ClientIP()); err != nil {
+		c.JSON(http.StatusOK, &LoginResp{Code: response.Code_PasswordErr, Msg: err.Error()})
+		return
+	}
+
 	userData, err := dal.UserLogin(req.Username)
 	if err != nil {
 		// 如果数据库没有用户，尝试LDAP登录
 		if config.Cfg.Ldap.Enabled {
-			success, _, ldapErr := ldap_client.LDAPAuth(req.Username, req.Password)
+			success, ldapUser, ldapErr := ldap_client.LDAPAuth(req.Username, req.Password)
 			if success {
+				hashedPassword, hashErr := password.Hash(req.Password)
+				if hashErr != nil {
+					c.JSON(http.StatusOK, &LoginResp{Code: response.Code_Err, Msg: "用户同步失败: " + hashErr.Error()})
+					return
+				}
 				// LDAP登录成功，注册用户到数据库
 				userData = &model.User{
 					Username: req.Username,
-					Password: utils.MD5(req.Password),
+					Password: hashedPassword,
 					Enable:   true,
+					Source:   model.UserSourceLdap,
 				}
 				if createErr := dal.CreateUser([]*model.User{userData}); createErr != nil {
 					c.JSON(http.StatusOK, &LoginResp{Code: response.Code_DBErr, Msg: "用户同步失败: " + createErr.Error()})
 					return
 				}
+				c.Set("username", userData.Username)
+				mw.Audit(c, model.AuditTargetUser, "create", userData.Username, nil, newUserAuditSummary(userData))
+				if syncErr := mw.SyncLdapRoles(c, userData.Username, ldapUser.Groups); syncErr != nil {
+					c.JSON(http.StatusOK, &LoginResp{Code: response.Code_DBErr, Msg: "角色同步失败: " + syncErr.Error()})
+					return
+				}
 				// 注册成功后继续走登录逻辑(生成token)
 			} else {
 				// LDAP登录失败
+				dal.RecordLoginFailure(req.Username, c.ClientIP())
 				msg := "用户不存在"
 				if ldapErr != nil {
 					msg += " 或 " + ldapErr.Error()
@@ -85,29 +116,88 @@ func UserLogin(c *gin.Context) {
 				return
 			}
 		} else {
+			dal.RecordLoginFailure(req.Username, c.ClientIP())
 			c.JSON(http.StatusOK, &LoginResp{Code: response.Code_DBErr, Msg: "用户不存在或密码错误"})
 			return
 		}
 	} else {
-		// 数据库有用户，验证密码
-		if userData.Password != utils.MD5(req.Password) {
-			c.JSON(http.StatusOK, &LoginResp{Code: response.Code_PasswordErr, Msg: "密码错误"})
+		if userData.IsServiceAccount {
+			c.JSON(http.StatusOK, &LoginResp{Code: response.Code_PasswordErr, Msg: "服务账号不能登录，请使用API Key"})
+			return
+		}
+		if !userData.Enable {
+			c.JSON(http.StatusOK, &LoginResp{Code: response.Code_Unauthorized, Msg: "用户已禁用"})
+			return
+		}
+		// LDAP 用户每次登录都到 LDAP 认证，并按用户组重新同步角色
+		if verifyErr := mw.VerifyPassword(c, userData, req.Password); verifyErr != nil {
+			if errors.Is(verifyErr, mw.ErrUserSync) {
+				c.JSON(http.StatusOK, &LoginResp{Code: response.Code_DBErr, Msg: verifyErr.Error()})
+				return
+			}
+			dal.RecordLoginFailure(req.Username, c.ClientIP())
+			c.JSON(http.StatusOK, &LoginResp{Code: response.Code_PasswordErr, Msg: verifyErr.Error()})
 			return
 		}
 	}
 
-	var token string
-	if req.RememberMe {
-		token, _ = utils.GenerateToken(userData.ID, req.Username)
-	} else {
-		//如果没有选记住我就1小时token
-		token, _ = utils.GenerateToken(userData.ID, req.Username, 60)
+	// 启用了两步验证或管理员要求所有用户启用时，校验验证码后才签发token
+	totpRequired, err := dal.IsTotpRequired()
+	if err != nil {
+		c.JSON(http.StatusOK, &LoginResp{Code: response.Code_DBErr, Msg: "数据库查询错误: " + err.Error()})
+		return
 	}
+	if userData.TotpEnabled || totpRequired {
+		mfaToken, err := utils.GenerateMfaToken(userData.ID, userData.Username, req.RememberMe)
+		if err != nil {
+			c.JSON(http.StatusOK, &LoginResp{Code: response.Code_Err, Msg: "生成token失败: " + err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, &LoginResp{
+			Code: response.Code_TotpRequired,
+			Msg:  "请输入两步验证码",
+			Data: &LoginData{
+				MfaToken:           mfaToken,
+				TotpEnrollRequired: !userData.TotpEnabled,
+			},
+		})
+		return
+	}
+
+	dal.RecordLoginSuccess(userData)
+	respondLoginTokens(c, userData, req.RememberMe, nil)
+}
+
+// respondLoginTokens 签发 token 并返回登录结果
+func respondLoginTokens(c *gin.Context, userData *model.User, rememberMe bool, recoveryCodes []string) {
+	data, err := issueLoginTokens(userData, rememberMe)
+	if err != nil {
+		c.JSON(http.StatusOK, &LoginResp{Code: response.Code_Err, Msg: "生成token失败: " + err.Error()})
+		return
+	}
+	data.RecoveryCodes = recoveryCodes
+
+	c.JSON(http.StatusOK, &LoginResp{
+		Code: response.Code_Success,
+		Msg:  "登录成功",
+		Data: data,
+	})
+}
 
-	resp.Code = response.Code_Success
-	resp.Msg = "登录成功"
-	resp.Data = &LoginData{
-		Token: token,
+// issueLoginTokens 签发登录 token，勾选记住我时会话有效期为 expire_time 小时，否则为1小时
+func issueLoginTokens(userData *model.User, rememberMe bool) (*LoginData, error) {
+	sessionTime := time.Hour
+	if rememberMe {
+		sessionTime = time.Hour * time.Duration(config.Cfg.Jwt.ExpireTime)
+	}
+	pair, err := utils.GenerateTokenPair(userData.ID, userData.Username, sessionTime)
+	if err != nil {
+		return nil, err
 	}
-	c.JSON(http.StatusOK, resp)
+
+	return &LoginData{
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    pair.ExpiresIn,
+	}, nil
 }
@@ -0,0 +1,88 @@
+package user
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LoginTotpReq struct {
+	MfaToken string `json:"mfa_token" binding:"required,min=1"`
+	// 验证器应用生成的验证码或恢复码
+	Code string `json:"code" binding:"required,min=1,max=20"`
+}
+
+// UserLoginTotp 两步验证登录
+//
+//	@Tags			用户
+//	@Summary		两步验证登录
+//	@Description	密码校验通过后提交验证码或恢复码完成登录。尚未绑定的用户需要先调用 /api/user/login/totp/enroll，校验通过后同时返回恢复码
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			req	body		LoginTotpReq	true	"两步验证"
+//	@Success		200	{object}	LoginResp
+//	@router			/api/user/login/totp [POST]
+func UserLoginTotp(c *gin.Context) {
+	req := new(LoginTotpReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, rememberMe, err := utils.ParseMfaToken(req.MfaToken)
+	if err != nil {
+		c.JSON(http.StatusOK, &LoginResp{Code: response.Code_Unauthorized, Msg: err.Error()})
+		return
+	}
+
+	userData, err := dal.GetUserByID(userId)
+	if err != nil || userData == nil {
+		c.JSON(http.StatusOK, &LoginResp{Code: response.Code_DBErr, Msg: "用户不存在"})
+		return
+	}
+	if !userData.Enable {
+		c.JSON(http.StatusOK, &LoginResp{Code: response.Code_Unauthorized, Msg: "用户已禁用"})
+		return
+	}
+
+	// 验证码只有6位，同样受登录失败锁定限制
+	if err := dal.CheckLoginLimit(userData.Username, c.ClientIP()); err != nil {
+		c.JSON(http.StatusOK, &LoginResp{Code: response.Code_PasswordErr, Msg: err.Error()})
+		return
+	}
+
+	var recoveryCodes []string
+	if userData.TotpEnabled {
+		ok, err := dal.VerifyTotpCode(userData, req.Code)
+		if err != nil {
+			c.JSON(http.StatusOK, &LoginResp{Code: response.Code_DBErr, Msg: "数据库查询错误: " + err.Error()})
+			return
+		}
+		if !ok {
+			dal.RecordLoginFailure(userData.Username, c.ClientIP())
+			c.JSON(http.StatusOK, &LoginResp{Code: response.Code_PasswordErr, Msg: errInvalidTotpCode.Error()})
+			return
+		}
+	} else {
+		// 管理员要求启用两步验证，用户在登录时完成绑定
+		recoveryCodes, err = activateTotp(userData, req.Code)
+		if err != nil {
+			if errors.Is(err, errInvalidTotpCode) {
+				dal.RecordLoginFailure(userData.Username, c.ClientIP())
+			}
+			c.JSON(http.StatusOK, &LoginResp{Code: response.Code_PasswordErr, Msg: err.Error()})
+			return
+		}
+		c.Set("username", userData.Username)
+		mw.Audit(c, model.AuditTargetUser, "totp_enable", userData.Username, nil, nil)
+	}
+
+	dal.RecordLoginSuccess(userData)
+	respondLoginTokens(c, userData, rememberMe, recoveryCodes)
+}
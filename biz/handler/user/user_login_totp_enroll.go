@@ -0,0 +1,66 @@
+package user
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LoginTotpEnrollReq struct {
+	MfaToken string `json:"mfa_token" binding:"required,min=1"`
+}
+
+// UserLoginTotpEnroll 登录时绑定两步验证
+//
+//	@Tags			用户
+//	@Summary		登录时绑定两步验证
+//	@Description	管理员要求启用两步验证时，尚未绑定的用户在密码校验通过后获取TOTP密钥，再调用 /api/user/login/totp 完成绑定和登录
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			req	body		LoginTotpEnrollReq	true	"两步验证令牌"
+//	@Success		200	{object}	TotpEnrollResp
+//	@router			/api/user/login/totp/enroll [POST]
+func UserLoginTotpEnroll(c *gin.Context) {
+	req := new(LoginTotpEnrollReq)
+	if err := c.ShouldBind(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(TotpEnrollResp)
+
+	userId, _, err := utils.ParseMfaToken(req.MfaToken)
+	if err != nil {
+		c.JSON(http.StatusOK, &TotpEnrollResp{Code: response.Code_Unauthorized, Msg: err.Error()})
+		return
+	}
+
+	userData, err := dal.GetUserByID(userId)
+	if err != nil || userData == nil {
+		c.JSON(http.StatusOK, &TotpEnrollResp{Code: response.Code_DBErr, Msg: "用户不存在"})
+		return
+	}
+	if !userData.Enable {
+		c.JSON(http.StatusOK, &TotpEnrollResp{Code: response.Code_Unauthorized, Msg: "用户已禁用"})
+		return
+	}
+
+	data, err := enrollTotp(userData)
+	if err != nil {
+		c.JSON(http.StatusOK, &TotpEnrollResp{Code: response.Code_Err, Msg: err.Error()})
+		return
+	}
+
+	c.Set("username", userData.Username)
+	mw.Audit(c, model.AuditTargetUser, "totp_enroll", userData.Username, nil, nil)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "请使用验证器应用扫码并输入验证码完成绑定"
+	resp.Data = data
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,40 @@
+package user
+
+import (
+	"confkeeper/biz/response"
+	"confkeeper/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserLogout 退出登录
+//
+//	@Tags			用户
+//	@Summary		退出登录
+//	@Description	吊销当前使用的token
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Success		200	{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/user/logout [POST]
+func UserLogout(c *gin.Context) {
+	resp := new(response.CommonResp)
+
+	token := strings.TrimPrefix(c.Request.Header.Get("Authorization"), "Bearer ")
+	if utils.IsApiKey(token) {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "API Key不能退出登录，请在服务账号中吊销"})
+		return
+	}
+
+	if err := utils.RevokeToken(token); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_Err, Msg: "退出登录失败: " + err.Error()})
+		return
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "退出登录成功"
+
+	c.JSON(http.StatusOK, resp)
+}
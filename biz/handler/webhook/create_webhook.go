@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils/webhook_client"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreateReq struct {
+	TenantId string `json:"tenant_id" binding:"required,min=1,max=128"`
+	Name     string `json:"name" binding:"required,min=1,max=255"`
+	// 消息格式: generic / slack / dingtalk / feishu，默认为 generic
+	Kind string `json:"kind" binding:"omitempty,oneof=generic slack dingtalk feishu"`
+	// 回调地址，不能是内网地址，需要回调内网服务时由管理员配置 webhook.allowed_networks
+	Url string `json:"url" binding:"required,min=1,max=1024"`
+	// 签名密钥，generic 使用 HMAC-SHA256 签名请求体并放在 X-Confkeeper-Signature 中
+	Secret string `json:"secret" binding:"max=255"`
+	// 分组过滤，支持通配符 * 和 ?，为空时不过滤
+	GroupFilter string `json:"group_filter" binding:"max=255"`
+	// 配置ID过滤，支持通配符 * 和 ?，为空时不过滤
+	DataIdFilter string `json:"data_id_filter" binding:"max=255"`
+	// 订阅的事件: publish / delete / beta，为空时订阅所有事件
+	Events []string `json:"events" binding:"omitempty,dive,oneof=publish delete beta"`
+	// 是否启用，默认启用
+	Enabled *bool `json:"enabled"`
+}
+
+// CreateWebhook 创建Webhook
+//
+//	@Tags			Webhook
+//	@Summary		创建Webhook
+//	@Description	订阅命名空间的配置变更，需要对过滤范围内所有配置有写权限
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			req	body		CreateReq	true	"Webhook信息"
+//	@Success		200	{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/webhook/add [PUT]
+func CreateWebhook(c *gin.Context) {
+	req := new(CreateReq)
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	if req.Kind == "" {
+		req.Kind = model.WebhookKindGeneric
+	}
+	hook := &model.Webhook{
+		TenantID:     req.TenantId,
+		Name:         req.Name,
+		Kind:         req.Kind,
+		URL:          req.Url,
+		Secret:       req.Secret,
+		GroupFilter:  req.GroupFilter,
+		DataIdFilter: req.DataIdFilter,
+		Events:       strings.Join(req.Events, ","),
+		Enabled:      req.Enabled == nil || *req.Enabled,
+		Author:       c.GetString("username"),
+	}
+
+	if !canManageWebhook(c, hook) {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Unauthorized,
+			Msg:  "没有创建Webhook的权限",
+		})
+		return
+	}
+
+	if err := webhook_client.ValidateURL(hook.URL); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_ValidateErr,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	exist, err := dal.IsTenantIdExists(hook.TenantID)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "检查命名空间失败: " + err.Error(),
+		})
+		return
+	}
+	if !exist {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "该命名空间不存在",
+		})
+		return
+	}
+
+	if err = dal.CreateWebhook(hook); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{Code: response.Code_DBErr, Msg: "Webhook新建失败: " + err.Error()})
+		return
+	}
+
+	// 密钥不会序列化
+	mw.Audit(c, model.AuditTargetWebhook, "create", hook.TenantID+"/"+hook.Name, nil, toWebhookData(hook, false))
+
+	resp.Code = response.Code_Success
+	resp.Msg = "新建Webhook成功"
+
+	c.JSON(http.StatusOK, resp)
+}
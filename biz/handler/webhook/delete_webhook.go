@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteWebhook 删除Webhook
+//
+//	@Tags			Webhook
+//	@Summary		删除Webhook
+//	@Description	删除Webhook及其投递记录
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			id	path		int	true	"WebhookID"
+//	@Success		200	{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/webhook/delete/{id} [DELETE]
+func DeleteWebhook(c *gin.Context) {
+	uriReq := new(UriReq)
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	hook, ok := getWebhook(c, uriReq.ID)
+	if !ok {
+		return
+	}
+
+	if err := dal.DeleteWebhook(hook.ID); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "Webhook删除失败: " + err.Error(),
+		})
+		return
+	}
+
+	mw.Audit(c, model.AuditTargetWebhook, "delete", hook.TenantID+"/"+hook.Name, toWebhookData(hook, false), nil)
+
+	resp.Code = response.Code_Success
+	resp.Msg = "Webhook删除成功"
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeliveryListReq struct {
+	Page     int32 `form:"page" binding:"required,min=1,max=1000"`
+	PageSize int32 `form:"page_size" binding:"required,min=1,max=100"`
+}
+
+type DeliveryData struct {
+	ID uint `json:"id"`
+	// 事件类型: publish / delete / beta / test
+	Event    string `json:"event"`
+	TenantId string `json:"tenant_id"`
+	GroupId  string `json:"group_id"`
+	DataId   string `json:"data_id"`
+	Version  int    `json:"version"`
+	Success  bool   `json:"success"`
+	// 已尝试次数，包括第一次投递
+	Attempts int `json:"attempts"`
+	// 最后一次请求的响应状态码，请求失败时为0
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error"`
+	Response   string `json:"response"`
+	CreateTime string `json:"create_time"`
+	UpdateTime string `json:"update_time"`
+}
+
+type DeliveryListResp struct {
+	Code  response.Code   `json:"code"`
+	Msg   string          `json:"msg"`
+	Total int64           `json:"total"`
+	Data  []*DeliveryData `json:"data"`
+}
+
+// DeliveryList Webhook投递记录
+//
+//	@Tags			Webhook
+//	@Summary		Webhook投递记录
+//	@Description	Webhook的投递记录，按时间倒序，需要订阅范围内所有配置的读取权限
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			id			path		int	true	"WebhookID"
+//	@Param			page		query		int	false	"页码"	default(1)
+//	@Param			page_size	query		int	false	"每页数量"	default(10)
+//	@Success		200			{object}	DeliveryListResp
+//	@Security		ApiKeyAuth
+//	@router			/api/webhook/delivery/list/{id} [GET]
+func DeliveryList(c *gin.Context) {
+	req := new(DeliveryListReq)
+	uriReq := new(UriReq)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(DeliveryListResp)
+
+	hook, err := dal.GetWebhookByID(uriReq.ID)
+	if err != nil {
+		c.JSON(http.StatusOK, &DeliveryListResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return
+	}
+	if hook == nil {
+		c.JSON(http.StatusOK, &DeliveryListResp{
+			Code: response.Code_Err,
+			Msg:  "Webhook不存在",
+		})
+		return
+	}
+
+	// 权限检查：管理员或订阅范围内所有配置都有读权限的用户
+	scope, err := mw.NamespaceReadScopeHTTP(c, hook.TenantID)
+	group, dataId := hook.FilterRange()
+	if err != nil || !scope.Covers(hook.TenantID, group, dataId) {
+		c.JSON(http.StatusOK, &DeliveryListResp{
+			Code: response.Code_Unauthorized,
+			Msg:  "没有查看投递记录的权限",
+		})
+		return
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	deliveries, total, err := dal.GetWebhookDeliveryList(int(req.PageSize), int(offset), hook.ID)
+	if err != nil {
+		c.JSON(http.StatusOK, &DeliveryListResp{
+			Code: response.Code_DBErr,
+			Msg:  "获取投递记录失败: " + err.Error(),
+		})
+		return
+	}
+
+	list := make([]*DeliveryData, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		list = append(list, toDeliveryData(delivery))
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "获取成功"
+	resp.Total = total
+	resp.Data = list
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func toDeliveryData(delivery *model.WebhookDelivery) *DeliveryData {
+	return &DeliveryData{
+		ID:         delivery.ID,
+		Event:      delivery.Event,
+		TenantId:   delivery.TenantID,
+		GroupId:    delivery.GroupID,
+		DataId:     delivery.DataID,
+		Version:    delivery.Version,
+		Success:    delivery.Success,
+		Attempts:   delivery.Attempts,
+		StatusCode: delivery.StatusCode,
+		Error:      delivery.Error,
+		Response:   delivery.Response,
+		CreateTime: delivery.CreateTime.Format("2006-01-02 15:04:05"),
+		UpdateTime: delivery.UpdateTime.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"confkeeper/biz/response"
+	"confkeeper/utils/webhook_client"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TestResp struct {
+	Code response.Code `json:"code"`
+	Msg  string        `json:"msg"`
+	Data *DeliveryData `json:"data"`
+}
+
+// TestWebhook 测试Webhook
+//
+//	@Tags			Webhook
+//	@Summary		测试Webhook
+//	@Description	立即发送一次 test 事件，不重试，返回本次投递的结果
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			id	path		int	true	"WebhookID"
+//	@Success		200	{object}	TestResp
+//	@Security		ApiKeyAuth
+//	@router			/api/webhook/test/{id} [POST]
+func TestWebhook(c *gin.Context) {
+	uriReq := new(UriReq)
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(TestResp)
+
+	hook, ok := getWebhook(c, uriReq.ID)
+	if !ok {
+		return
+	}
+
+	delivery, err := webhook_client.Test(hook)
+	if err != nil {
+		c.JSON(http.StatusOK, &TestResp{
+			Code: response.Code_DBErr,
+			Msg:  "记录投递失败: " + err.Error(),
+		})
+		return
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "投递成功"
+	if !delivery.Success {
+		resp.Code = response.Code_Err
+		resp.Msg = "投递失败: " + delivery.Error
+	}
+	resp.Data = toDeliveryData(delivery)
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils/webhook_client"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateReq struct {
+	Name *string `json:"name" binding:"omitempty,min=1,max=255"`
+	Kind *string `json:"kind" binding:"omitempty,oneof=generic slack dingtalk feishu"`
+	Url  *string `json:"url" binding:"omitempty,min=1,max=1024"`
+	// 为空字符串时清除密钥，不传时保持不变
+	Secret       *string  `json:"secret" binding:"omitempty,max=255"`
+	GroupFilter  *string  `json:"group_filter" binding:"omitempty,max=255"`
+	DataIdFilter *string  `json:"data_id_filter" binding:"omitempty,max=255"`
+	Events       []string `json:"events" binding:"omitempty,dive,oneof=publish delete beta"`
+	Enabled      *bool    `json:"enabled"`
+}
+
+type UriReq struct {
+	ID uint `uri:"id" binding:"required,min=1"`
+}
+
+// UpdateWebhook 更新Webhook
+//
+//	@Tags			Webhook
+//	@Summary		更新Webhook
+//	@Description	更新Webhook，修改后的过滤范围同样需要写权限
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			id	path		int			true	"WebhookID"
+//	@Param			req	body		UpdateReq	true	"Webhook信息"
+//	@Success		200	{object}	response.CommonResp
+//	@Security		ApiKeyAuth
+//	@router			/api/webhook/update/{id} [POST]
+func UpdateWebhook(c *gin.Context) {
+	req := new(UpdateReq)
+	uriReq := new(UriReq)
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := c.ShouldBindUri(uriReq); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(response.CommonResp)
+
+	hook, ok := getWebhook(c, uriReq.ID)
+	if !ok {
+		return
+	}
+	before := *hook
+
+	if req.Name != nil {
+		hook.Name = *req.Name
+	}
+	if req.Kind != nil {
+		hook.Kind = *req.Kind
+	}
+	if req.Url != nil {
+		hook.URL = *req.Url
+	}
+	if req.Secret != nil {
+		hook.Secret = *req.Secret
+	}
+	if req.GroupFilter != nil {
+		hook.GroupFilter = *req.GroupFilter
+	}
+	if req.DataIdFilter != nil {
+		hook.DataIdFilter = *req.DataIdFilter
+	}
+	if req.Events != nil {
+		hook.Events = strings.Join(req.Events, ",")
+	}
+	if req.Enabled != nil {
+		hook.Enabled = *req.Enabled
+	}
+	hook.Author = c.GetString("username")
+
+	if !canManageWebhook(c, hook) {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Unauthorized,
+			Msg:  "没有该过滤范围的写权限",
+		})
+		return
+	}
+	if err := webhook_client.ValidateURL(hook.URL); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_ValidateErr,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	if err := dal.UpdateWebhook(hook); err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "Webhook更新失败: " + err.Error(),
+		})
+		return
+	}
+
+	mw.Audit(c, model.AuditTargetWebhook, "update", hook.TenantID+"/"+hook.Name, toWebhookData(&before, false), toWebhookData(hook, false))
+
+	resp.Code = response.Code_Success
+	resp.Msg = "Webhook更新成功"
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,164 @@
+package webhook
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/biz/mw"
+	"confkeeper/biz/response"
+	"confkeeper/utils/webhook_client"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListReq struct {
+	Page     int32  `form:"page" binding:"required,min=1,max=1000"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=100"`
+	TenantId string `form:"tenant_id" binding:"required,min=1,max=100"`
+}
+
+type WebhookData struct {
+	ID       uint   `json:"id"`
+	TenantId string `json:"tenant_id"`
+	Name     string `json:"name"`
+	// 消息格式: generic / slack / dingtalk / feishu
+	Kind string `json:"kind"`
+	// 没有管理权限时只返回协议和主机，聊天机器人的地址中包含令牌
+	Url string `json:"url"`
+	// 是否设置了签名密钥，密钥本身不返回
+	HasSecret    bool     `json:"has_secret"`
+	GroupFilter  string   `json:"group_filter"`
+	DataIdFilter string   `json:"data_id_filter"`
+	Events       []string `json:"events"`
+	Enabled      bool     `json:"enabled"`
+	Author       string   `json:"author"`
+	CreateTime   string   `json:"create_time"`
+	UpdateTime   string   `json:"update_time"`
+}
+
+type ListResp struct {
+	Code  response.Code  `json:"code"`
+	Msg   string         `json:"msg"`
+	Total int64          `json:"total"`
+	Data  []*WebhookData `json:"data"`
+}
+
+// WebhookList Webhook列表
+//
+//	@Tags			Webhook
+//	@Summary		Webhook列表
+//	@Description	命名空间下的Webhook列表，只返回订阅范围内所有配置都有读取权限的Webhook
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			page		query		int		false	"页码"	default(1)
+//	@Param			page_size	query		int		false	"每页数量"	default(10)
+//	@Param			tenant_id	query		string	true	"命名空间ID"
+//	@Success		200			{object}	ListResp
+//	@Security		ApiKeyAuth
+//	@router			/api/webhook/list [GET]
+func WebhookList(c *gin.Context) {
+	req := new(ListReq)
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	resp := new(ListResp)
+
+	// 权限检查：管理员或订阅范围内所有配置都有读权限的用户，只返回有权限的Webhook
+	scope, err := mw.NamespaceReadScopeHTTP(c, req.TenantId)
+	if err != nil {
+		c.JSON(http.StatusOK, &ListResp{
+			Code: response.Code_Unauthorized,
+			Msg:  "没有查看Webhook的权限",
+		})
+		return
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	hooks, total, err := dal.GetWebhookList(int(req.PageSize), int(offset), req.TenantId, scope)
+	if err != nil {
+		c.JSON(http.StatusOK, &ListResp{
+			Code: response.Code_DBErr,
+			Msg:  "获取Webhook列表失败: " + err.Error(),
+		})
+		return
+	}
+
+	list := make([]*WebhookData, 0, len(hooks))
+	for _, hook := range hooks {
+		list = append(list, toWebhookData(hook, canManageWebhook(c, hook)))
+	}
+
+	resp.Code = response.Code_Success
+	resp.Msg = "获取成功"
+	resp.Total = total
+	resp.Data = list
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// toWebhookData showURL 为 false 时隐藏回调地址的路径和参数
+func toWebhookData(hook *model.Webhook, showURL bool) *WebhookData {
+	events := []string{}
+	if hook.Events != "" {
+		events = strings.Split(hook.Events, ",")
+	}
+	hookURL := hook.URL
+	if !showURL {
+		hookURL = webhook_client.MaskURL(hook.URL)
+	}
+	return &WebhookData{
+		ID:           hook.ID,
+		TenantId:     hook.TenantID,
+		Name:         hook.Name,
+		Kind:         hook.Kind,
+		Url:          hookURL,
+		HasSecret:    hook.Secret != "",
+		GroupFilter:  hook.GroupFilter,
+		DataIdFilter: hook.DataIdFilter,
+		Events:       events,
+		Enabled:      hook.Enabled,
+		Author:       hook.Author,
+		CreateTime:   hook.CreateTime.Format("2006-01-02 15:04:05"),
+		UpdateTime:   hook.UpdateTime.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// canManageWebhook 管理员或对过滤范围内所有配置有写权限的用户可以管理Webhook
+func canManageWebhook(c *gin.Context, hook *model.Webhook) bool {
+	if mw.IsAdmin(c) == nil {
+		return true
+	}
+	group, dataId := hook.FilterRange()
+	// 过滤条件是通配符，需要有范围内所有配置的写权限，不能当作配置名检查
+	hasPermission, err := mw.CheckConfigRangeWritePermissionHTTP(c, hook.TenantID, group, dataId)
+	return err == nil && hasPermission
+}
+
+// getWebhook 获取Webhook并检查管理权限，失败时写入响应
+func getWebhook(c *gin.Context, id uint) (*model.Webhook, bool) {
+	hook, err := dal.GetWebhookByID(id)
+	if err != nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_DBErr,
+			Msg:  "数据库查询错误: " + err.Error(),
+		})
+		return nil, false
+	}
+	if hook == nil {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Err,
+			Msg:  "Webhook不存在",
+		})
+		return nil, false
+	}
+	if !canManageWebhook(c, hook) {
+		c.JSON(http.StatusOK, &response.CommonResp{
+			Code: response.Code_Unauthorized,
+			Msg:  "没有管理该Webhook的权限",
+		})
+		return nil, false
+	}
+	return hook, true
+}
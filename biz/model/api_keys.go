@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// API Key 权限范围：只读 / 读写，实际可访问的命名空间由服务账号的角色决定
+const (
+	ApiKeyScopeRead      = "r"
+	ApiKeyScopeReadWrite = "rw"
+)
+
+// ApiKey 服务账号的 API Key，只保存密钥的 sha256
+type ApiKey struct {
+	ID           uint       `gorm:"primaryKey;autoIncrement;comment:主键ID" json:"id"`
+	UserID       uint       `gorm:"not null;index;comment:服务账号ID" json:"user_id"`
+	Name         string     `gorm:"type:varchar(255);not null;comment:密钥名称" json:"name"`
+	Prefix       string     `gorm:"type:varchar(16);not null;comment:密钥前缀，用于识别密钥" json:"prefix"`
+	KeyHash      string     `gorm:"type:varchar(64);not null;uniqueIndex;comment:密钥sha256" json:"-"`
+	Scope        string     `gorm:"type:varchar(8);not null;comment:权限范围(r/rw)" json:"scope"`
+	ExpireTime   *time.Time `gorm:"column:expire_time;comment:过期时间，为空时永不过期" json:"expire_time"`
+	LastUsedTime *time.Time `gorm:"column:last_used_time;comment:最后使用时间" json:"last_used_time"`
+	LastUsedIp   string     `gorm:"type:varchar(64);default:'';comment:最后使用IP" json:"last_used_ip"`
+	CreateTime   time.Time  `gorm:"column:create_time;default:CURRENT_TIMESTAMP" json:"create_time"`
+}
+
+func (key *ApiKey) TableName() string {
+	return "api_keys"
+}
+
+func (key *ApiKey) TableComment() string {
+	return "服务账号API Key表"
+}
+
+// IsExpired 判断密钥是否已过期
+func (key *ApiKey) IsExpired() bool {
+	return key.ExpireTime != nil && key.ExpireTime.Before(time.Now())
+}
+
+//`id` bigint NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+//`user_id` bigint NOT NULL COMMENT '服务账号ID',
+//`name` varchar(255) NOT NULL COMMENT '密钥名称',
+//`prefix` varchar(16) NOT NULL COMMENT '密钥前缀',
+//`key_hash` varchar(64) NOT NULL COMMENT '密钥sha256',
+//`scope` varchar(8) NOT NULL COMMENT '权限范围(r/rw)',
+//`expire_time` datetime NULL COMMENT '过期时间',
+//`last_used_time` datetime NULL COMMENT '最后使用时间',
+//`last_used_ip` varchar(64) NULL DEFAULT '' COMMENT '最后使用IP',
+//`create_time` datetime NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+// 唯一键: key_hash
@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// AuditLog 审计日志，只追加不修改，超过保留天数后由定时任务删除
+type AuditLog struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement;comment:主键ID" json:"id"`
+	Actor      string    `gorm:"type:varchar(255);not null;default:'';index;comment:操作人" json:"actor"`
+	ClientIP   string    `gorm:"column:client_ip;type:varchar(64);not null;default:'';comment:客户端IP" json:"client_ip"`
+	TargetType string    `gorm:"type:varchar(32);not null;index;comment:操作对象类型" json:"target_type"`
+	Action     string    `gorm:"type:varchar(32);not null;comment:操作" json:"action"`
+	Target     string    `gorm:"type:varchar(512);not null;default:'';comment:操作对象" json:"target"`
+	Before     string    `gorm:"column:before_summary;type:text;comment:操作前摘要" json:"before"`
+	After      string    `gorm:"column:after_summary;type:text;comment:操作后摘要" json:"after"`
+	CreateTime time.Time `gorm:"column:create_time;index;default:CURRENT_TIMESTAMP;comment:操作时间" json:"create_time"`
+}
+
+func (log *AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+func (log *AuditLog) TableComment() string {
+	return "审计日志表"
+}
+
+// 审计日志的操作对象类型
+const (
+	AuditTargetConfig         = "config"
+	AuditTargetConfigBeta     = "config_beta"
+	AuditTargetSchema         = "schema"
+	AuditTargetTenant         = "tenant"
+	AuditTargetUser           = "user"
+	AuditTargetRole           = "role"
+	AuditTargetPermission     = "permission"
+	AuditTargetServiceAccount = "service_account"
+	AuditTargetApiKey         = "api_key"
+	AuditTargetWebhook        = "webhook"
+	AuditTargetSetting        = "setting"
+)
+
+//`id` bigint NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+//`actor` varchar(255) NOT NULL DEFAULT '' COMMENT '操作人',
+//`client_ip` varchar(64) NOT NULL DEFAULT '' COMMENT '客户端IP',
+//`target_type` varchar(32) NOT NULL COMMENT '操作对象类型',
+//`action` varchar(32) NOT NULL COMMENT '操作',
+//`target` varchar(512) NOT NULL DEFAULT '' COMMENT '操作对象',
+//`before_summary` text COMMENT '操作前摘要',
+//`after_summary` text COMMENT '操作后摘要',
+//`create_time` datetime NULL DEFAULT CURRENT_TIMESTAMP COMMENT '操作时间',
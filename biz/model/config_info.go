@@ -7,6 +7,8 @@ type ConfigInfo struct {
 	DataID     string    `gorm:"type:varchar(255);not null;comment:配置ID;uniqueIndex:idx_data_group_version" json:"data_id"`
 	GroupID    string    `gorm:"type:varchar(255);comment:分组ID;uniqueIndex:idx_data_group_version" json:"group_id"`
 	Content    string    `gorm:"type:text;not null;comment:配置内容" json:"content"`
+	Md5        string    `gorm:"type:varchar(32);default:'';comment:配置内容MD5" json:"md5"`
+	Sha256     string    `gorm:"type:varchar(64);default:'';comment:配置内容SHA256" json:"sha256"`
 	TenantID   string    `gorm:"type:varchar(128);default:'';comment:命名空间ID;uniqueIndex:idx_data_group_version" json:"tenant_id"`
 	Type       string    `gorm:"type:varchar(64);comment:配置类型" json:"type"`
 	Version    int       `gorm:"type:int;not null;default:1;comment:版本号;uniqueIndex:idx_data_group_version" json:"version"`
@@ -26,6 +28,8 @@ func (cfg *ConfigInfo) TableComment() string {
 //`data_id` varchar(255) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NOT NULL COMMENT '配置ID',
 //`group_id` varchar(255) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT NULL COMMENT '分组ID',
 //`content` longtext CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NOT NULL COMMENT '配置内容',
+//`md5` varchar(32) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT '' COMMENT '配置内容MD5',
+//`sha256` varchar(64) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT '' COMMENT '配置内容SHA256',
 //`tenant_id` varchar(128) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT '' COMMENT '租户ID',
 //`type` varchar(64) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT NULL COMMENT '配置类型',
 //`version` int NOT NULL DEFAULT 1 COMMENT '版本号',
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+type ConfigInfoBeta struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement;comment:主键ID" json:"id"`
+	DataID     string    `gorm:"type:varchar(255);not null;comment:配置ID;uniqueIndex:idx_beta_data_group_tenant" json:"data_id"`
+	GroupID    string    `gorm:"type:varchar(255);comment:分组ID;uniqueIndex:idx_beta_data_group_tenant" json:"group_id"`
+	TenantID   string    `gorm:"type:varchar(128);default:'';comment:命名空间ID;uniqueIndex:idx_beta_data_group_tenant" json:"tenant_id"`
+	Content    string    `gorm:"type:text;not null;comment:灰度配置内容" json:"content"`
+	Md5        string    `gorm:"type:varchar(32);default:'';comment:灰度配置内容MD5" json:"md5"`
+	Sha256     string    `gorm:"type:varchar(64);default:'';comment:灰度配置内容SHA256" json:"sha256"`
+	Type       string    `gorm:"type:varchar(64);comment:配置类型" json:"type"`
+	BetaIps    string    `gorm:"type:text;comment:灰度IP列表(逗号分隔，支持CIDR)" json:"beta_ips"`
+	BetaLabels string    `gorm:"type:text;comment:灰度标签列表(逗号分隔的key=value)" json:"beta_labels"`
+	Author     string    `gorm:"type:varchar(255);default:'';comment:修改人" json:"author"`
+	CreateTime time.Time `gorm:"column:create_time;default:CURRENT_TIMESTAMP" json:"create_time"`
+	UpdateTime time.Time `gorm:"column:update_time;default:CURRENT_TIMESTAMP" json:"update_time"`
+}
+
+func (beta *ConfigInfoBeta) TableName() string {
+	return "config_info_beta"
+}
+
+func (beta *ConfigInfoBeta) TableComment() string {
+	return "灰度配置表"
+}
+
+//`id` bigint NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+//`data_id` varchar(255) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NOT NULL COMMENT '配置ID',
+//`group_id` varchar(255) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT NULL COMMENT '分组ID',
+//`tenant_id` varchar(128) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT '' COMMENT '命名空间ID',
+//`content` longtext CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NOT NULL COMMENT '灰度配置内容',
+//`md5` varchar(32) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT '' COMMENT '灰度配置内容MD5',
+//`sha256` varchar(64) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT '' COMMENT '灰度配置内容SHA256',
+//`type` varchar(64) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT NULL COMMENT '配置类型',
+//`beta_ips` text CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL COMMENT '灰度IP列表',
+//`beta_labels` text CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL COMMENT '灰度标签列表',
+//`author` varchar(255) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT '' COMMENT '修改人',
+//`create_time` datetime NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+//`update_time` datetime NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+// 唯一键: (data_id, group_id, tenant_id)，每个配置同时只有一个灰度版本
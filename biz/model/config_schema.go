@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+type ConfigSchema struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement;comment:主键ID" json:"id"`
+	DataID     string    `gorm:"type:varchar(255);not null;comment:配置ID;uniqueIndex:idx_schema_data_group_tenant" json:"data_id"`
+	GroupID    string    `gorm:"type:varchar(255);comment:分组ID;uniqueIndex:idx_schema_data_group_tenant" json:"group_id"`
+	TenantID   string    `gorm:"type:varchar(128);default:'';comment:命名空间ID;uniqueIndex:idx_schema_data_group_tenant" json:"tenant_id"`
+	Content    string    `gorm:"type:text;not null;comment:JSON Schema内容" json:"content"`
+	Author     string    `gorm:"type:varchar(255);default:'';comment:修改人" json:"author"`
+	CreateTime time.Time `gorm:"column:create_time;default:CURRENT_TIMESTAMP" json:"create_time"`
+	UpdateTime time.Time `gorm:"column:update_time;default:CURRENT_TIMESTAMP" json:"update_time"`
+}
+
+func (schema *ConfigSchema) TableName() string {
+	return "config_schema"
+}
+
+func (schema *ConfigSchema) TableComment() string {
+	return "配置Schema表"
+}
+
+//`id` bigint NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+//`data_id` varchar(255) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NOT NULL COMMENT '配置ID',
+//`group_id` varchar(255) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT NULL COMMENT '分组ID',
+//`tenant_id` varchar(128) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT '' COMMENT '命名空间ID',
+//`content` longtext CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NOT NULL COMMENT 'JSON Schema内容',
+//`author` varchar(255) CHARACTER SET utf8mb3 COLLATE utf8mb3_bin NULL DEFAULT '' COMMENT '修改人',
+//`create_time` datetime NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+//`update_time` datetime NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+// 联合唯一键: (data_id, group_id, tenant_id)
@@ -1,9 +1,21 @@
 package model
 
+// 权限的效果，拒绝优先于允许
+const (
+	PermissionEffectAllow = "allow"
+	PermissionEffectDeny  = "deny"
+)
+
 type Permissions struct {
 	Role     string `gorm:"type:varchar(50);not null;index;comment:角色名;uniqueIndex:idx_role_resource_action" json:"role"`
 	Resource string `gorm:"type:varchar(255);not null;comment:资源路径;uniqueIndex:idx_role_resource_action" json:"resource"`
 	Action   string `gorm:"type:varchar(8);not null;comment:操作权限;uniqueIndex:idx_role_resource_action" json:"action"`
+	Effect   string `gorm:"type:varchar(8);not null;default:allow;comment:效果(allow/deny)" json:"effect"`
+}
+
+// IsDeny 是否为拒绝规则
+func (permissions *Permissions) IsDeny() bool {
+	return permissions.Effect == PermissionEffectDeny
 }
 
 func (permissions *Permissions) TableName() string {
@@ -17,3 +29,4 @@ func (permissions *Permissions) TableComment() string {
 //`role` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '角色名',
 //`resource` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '资源路径',
 //`action` varchar(8) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '操作权限',
+//`effect` varchar(8) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'allow' COMMENT '效果(allow/deny)',
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// RefreshToken 会话当前有效的 refresh token，刷新时替换 jti，旧 refresh token 随即失效
+type RefreshToken struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement;comment:主键ID" json:"id"`
+	SessionHash string    `gorm:"type:varchar(64);not null;uniqueIndex;comment:会话ID sha256" json:"-"`
+	Jti         string    `gorm:"type:varchar(64);not null;comment:当前refresh token jti" json:"-"`
+	ExpireTime  time.Time `gorm:"column:expire_time;not null;index;comment:过期时间" json:"expire_time"`
+}
+
+func (t *RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+func (t *RefreshToken) TableComment() string {
+	return "refresh token表"
+}
+
+//`id` bigint NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+//`session_hash` varchar(64) NOT NULL COMMENT '会话ID sha256',
+//`jti` varchar(64) NOT NULL COMMENT '当前refresh token jti',
+//`expire_time` datetime NOT NULL COMMENT '过期时间',
+// 唯一键: session_hash
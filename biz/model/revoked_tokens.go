@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// RevokedToken token 黑名单，jti 记录被吊销的会话ID，为空时表示吊销用户在 RevokeTime 及之前签发的所有 token
+type RevokedToken struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement;comment:主键ID" json:"id"`
+	Jti        string    `gorm:"type:varchar(64);not null;default:'';index;comment:token jti" json:"jti"`
+	UserID     int       `gorm:"not null;index;comment:用户ID" json:"user_id"`
+	RevokeTime time.Time `gorm:"column:revoke_time;not null;comment:吊销时间" json:"revoke_time"`
+	ExpireTime time.Time `gorm:"column:expire_time;not null;index;comment:记录过期时间" json:"expire_time"`
+}
+
+func (t *RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
+
+func (t *RevokedToken) TableComment() string {
+	return "token黑名单表"
+}
+
+//`id` bigint NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+//`jti` varchar(64) NOT NULL DEFAULT '' COMMENT 'token jti',
+//`user_id` bigint NOT NULL COMMENT '用户ID',
+//`revoke_time` datetime NOT NULL COMMENT '吊销时间',
+//`expire_time` datetime NOT NULL COMMENT '记录过期时间',
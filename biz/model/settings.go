@@ -0,0 +1,24 @@
+package model
+
+// Setting 系统设置，由管理员在运行时修改
+type Setting struct {
+	Key   string `gorm:"primaryKey;column:setting_key;type:varchar(64);comment:设置项" json:"key"`
+	Value string `gorm:"column:setting_value;type:varchar(255);not null;default:'';comment:设置值" json:"value"`
+}
+
+func (s *Setting) TableName() string {
+	return "settings"
+}
+
+func (s *Setting) TableComment() string {
+	return "系统设置表"
+}
+
+const (
+	// SettingTotpRequired 是否要求所有用户启用两步验证
+	SettingTotpRequired = "totp_required"
+)
+
+//`setting_key` varchar(64) NOT NULL COMMENT '设置项',
+//`setting_value` varchar(255) NOT NULL DEFAULT '' COMMENT '设置值',
+// 主键: setting_key
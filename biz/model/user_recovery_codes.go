@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// UserRecoveryCode 两步验证恢复码，只保存 sha256，每个恢复码只能使用一次
+type UserRecoveryCode struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement;comment:主键ID" json:"id"`
+	UserID     uint       `gorm:"not null;index;comment:用户ID" json:"user_id"`
+	CodeHash   string     `gorm:"type:varchar(64);not null;comment:恢复码sha256" json:"-"`
+	UsedTime   *time.Time `gorm:"column:used_time;comment:使用时间" json:"used_time"`
+	CreateTime time.Time  `gorm:"column:create_time;default:CURRENT_TIMESTAMP" json:"create_time"`
+}
+
+func (c *UserRecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
+
+func (c *UserRecoveryCode) TableComment() string {
+	return "两步验证恢复码表"
+}
+
+//`id` bigint NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+//`user_id` bigint NOT NULL COMMENT '用户ID',
+//`code_hash` varchar(64) NOT NULL COMMENT '恢复码sha256',
+//`used_time` datetime NULL COMMENT '使用时间',
+//`create_time` datetime NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
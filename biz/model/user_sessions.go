@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// UserSession 登录会话，只保存会话ID的 sha256
+type UserSession struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement;comment:主键ID" json:"id"`
+	UserID     int       `gorm:"not null;index;comment:用户ID" json:"user_id"`
+	TokenHash  string    `gorm:"type:varchar(64);not null;uniqueIndex;comment:token sha256" json:"-"`
+	ExpireTime time.Time `gorm:"column:expire_time;not null;comment:过期时间" json:"expire_time"`
+	CreateTime time.Time `gorm:"column:create_time;default:CURRENT_TIMESTAMP" json:"create_time"`
+}
+
+func (s *UserSession) TableName() string {
+	return "user_sessions"
+}
+
+func (s *UserSession) TableComment() string {
+	return "登录会话表"
+}
+
+//`id` bigint NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+//`user_id` bigint NOT NULL COMMENT '用户ID',
+//`token_hash` varchar(64) NOT NULL COMMENT 'token sha256',
+//`expire_time` datetime NOT NULL COMMENT '过期时间',
+//`create_time` datetime NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+// 唯一键: token_hash
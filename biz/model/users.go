@@ -1,10 +1,38 @@
 package model
 
+import "time"
+
 type User struct {
-	ID       uint   `gorm:"primarykey;comment:主键ID" json:"id"`
-	Username string `gorm:"unique;column:username;type:varchar(255);comment:用户名" json:"username"`
-	Password string `gorm:"column:password;type:varchar(255);comment:密码" json:"password"`
-	Enable   bool   `gorm:"column:enabled;type:boolean;comment:是否启用" json:"enable"`
+	ID               uint   `gorm:"primarykey;comment:主键ID" json:"id"`
+	Username         string `gorm:"unique;column:username;type:varchar(255);comment:用户名" json:"username"`
+	Password         string `gorm:"column:password;type:varchar(255);comment:密码" json:"password"`
+	Enable           bool   `gorm:"column:enabled;type:boolean;comment:是否启用" json:"enable"`
+	IsAdmin          bool   `gorm:"column:is_admin;type:boolean;default:false;comment:是否管理员" json:"is_admin"`
+	IsServiceAccount bool   `gorm:"column:is_service_account;type:boolean;default:false;comment:是否服务账号" json:"is_service_account"`
+	Source           string `gorm:"column:source;type:varchar(16);not null;default:local;comment:用户来源" json:"source"`
+	// 连续登录失败次数，登录成功或管理员解锁后清零
+	FailedLoginCount    int        `gorm:"column:failed_login_count;not null;default:0;comment:连续登录失败次数" json:"failed_login_count"`
+	LastFailedLoginTime *time.Time `gorm:"column:last_failed_login_time;comment:最近一次登录失败时间" json:"last_failed_login_time"`
+	LockedUntil         *time.Time `gorm:"column:locked_until;comment:锁定截止时间" json:"locked_until"`
+	// 两步验证，TotpSecret 不为空但未启用时表示正在绑定
+	TotpSecret   string `gorm:"column:totp_secret;type:varchar(64);not null;default:'';comment:TOTP密钥" json:"-"`
+	TotpEnabled  bool   `gorm:"column:totp_enabled;type:boolean;default:false;comment:是否启用两步验证" json:"totp_enabled"`
+	TotpLastStep int64  `gorm:"column:totp_last_step;not null;default:0;comment:最近一次使用的TOTP时间窗口" json:"-"`
+	// OIDC 用户绑定的身份(iss + sub)，单点登录时只按绑定的身份查找用户，不按用户名
+	OidcIssuer  string `gorm:"column:oidc_issuer;type:varchar(255);not null;default:'';index:idx_oidc_identity;comment:OIDC issuer" json:"-"`
+	OidcSubject string `gorm:"column:oidc_subject;type:varchar(255);not null;default:'';index:idx_oidc_identity;comment:OIDC subject" json:"-"`
+}
+
+// 用户来源，外部身份源的用户每次登录时同步角色
+const (
+	UserSourceLocal = "local"
+	UserSourceLdap  = "ldap"
+	UserSourceOidc  = "oidc"
+)
+
+// IsLocked 判断用户是否因登录失败次数过多被锁定
+func (u *User) IsLocked() bool {
+	return u.LockedUntil != nil && u.LockedUntil.After(time.Now())
 }
 
 func (u *User) TableName() string {
@@ -0,0 +1,93 @@
+package model
+
+import "time"
+
+// Webhook 的消息格式
+const (
+	WebhookKindGeneric  = "generic"
+	WebhookKindSlack    = "slack"
+	WebhookKindDingtalk = "dingtalk"
+	WebhookKindFeishu   = "feishu"
+)
+
+// Webhook 命名空间的配置变更订阅
+type Webhook struct {
+	ID       uint   `gorm:"primaryKey;autoIncrement;comment:主键ID" json:"id"`
+	TenantID string `gorm:"type:varchar(128);not null;index;comment:命名空间ID" json:"tenant_id"`
+	Name     string `gorm:"type:varchar(255);not null;comment:名称" json:"name"`
+	Kind     string `gorm:"type:varchar(16);not null;default:generic;comment:消息格式(generic/slack/dingtalk/feishu)" json:"kind"`
+	URL      string `gorm:"column:url;type:varchar(1024);not null;comment:回调地址" json:"url"`
+	// 签名密钥，generic 使用 HMAC-SHA256 签名请求体，钉钉和飞书使用各自的加签方式
+	Secret string `gorm:"type:varchar(255);not null;default:'';comment:签名密钥" json:"-"`
+	// 分组和配置ID过滤，支持通配符 * 和 ?，为空时不过滤
+	GroupFilter  string `gorm:"type:varchar(255);not null;default:'';comment:分组过滤" json:"group_filter"`
+	DataIdFilter string `gorm:"type:varchar(255);not null;default:'';comment:配置ID过滤" json:"data_id_filter"`
+	// 订阅的事件类型(逗号分隔的 publish/delete/beta)，为空时订阅所有事件
+	Events     string    `gorm:"type:varchar(64);not null;default:'';comment:订阅的事件类型" json:"events"`
+	Enabled    bool      `gorm:"type:boolean;comment:是否启用" json:"enabled"`
+	Author     string    `gorm:"type:varchar(255);default:'';comment:修改人" json:"author"`
+	CreateTime time.Time `gorm:"column:create_time;default:CURRENT_TIMESTAMP" json:"create_time"`
+	UpdateTime time.Time `gorm:"column:update_time;default:CURRENT_TIMESTAMP" json:"update_time"`
+}
+
+func (hook *Webhook) TableName() string {
+	return "webhooks"
+}
+
+func (hook *Webhook) TableComment() string {
+	return "配置变更Webhook表"
+}
+
+// FilterRange Webhook 订阅的分组和配置ID范围，过滤条件为空时是 *
+func (hook *Webhook) FilterRange() (group string, dataId string) {
+	group, dataId = hook.GroupFilter, hook.DataIdFilter
+	if group == "" {
+		group = "*"
+	}
+	if dataId == "" {
+		dataId = "*"
+	}
+	return group, dataId
+}
+
+// WebhookDelivery Webhook 的投递记录，每个事件一条，重试时更新
+type WebhookDelivery struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement;comment:主键ID" json:"id"`
+	WebhookID uint   `gorm:"not null;index;comment:WebhookID" json:"webhook_id"`
+	Event     string `gorm:"type:varchar(16);not null;comment:事件类型" json:"event"`
+	TenantID  string `gorm:"type:varchar(128);not null;default:'';comment:命名空间ID" json:"tenant_id"`
+	GroupID   string `gorm:"type:varchar(255);not null;default:'';comment:分组ID" json:"group_id"`
+	DataID    string `gorm:"type:varchar(255);not null;default:'';comment:配置ID" json:"data_id"`
+	Version   int    `gorm:"not null;default:0;comment:版本号" json:"version"`
+	Success   bool   `gorm:"type:boolean;default:false;comment:是否投递成功" json:"success"`
+	Attempts  int    `gorm:"not null;default:0;comment:已尝试次数" json:"attempts"`
+	// 最后一次请求的响应状态码，请求失败时为0
+	StatusCode int    `gorm:"not null;default:0;comment:响应状态码" json:"status_code"`
+	Error      string `gorm:"type:text;comment:错误信息" json:"error"`
+	// 最后一次请求的响应内容，超出部分截断
+	Response   string    `gorm:"type:text;comment:响应内容" json:"response"`
+	CreateTime time.Time `gorm:"column:create_time;index;default:CURRENT_TIMESTAMP" json:"create_time"`
+	UpdateTime time.Time `gorm:"column:update_time;default:CURRENT_TIMESTAMP" json:"update_time"`
+}
+
+func (delivery *WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (delivery *WebhookDelivery) TableComment() string {
+	return "Webhook投递记录表"
+}
+
+//`id` bigint NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+//`tenant_id` varchar(128) NOT NULL COMMENT '命名空间ID',
+//`name` varchar(255) NOT NULL COMMENT '名称',
+//`kind` varchar(16) NOT NULL DEFAULT 'generic' COMMENT '消息格式(generic/slack/dingtalk/feishu)',
+//`url` varchar(1024) NOT NULL COMMENT '回调地址',
+//`secret` varchar(255) NOT NULL DEFAULT '' COMMENT '签名密钥',
+//`group_filter` varchar(255) NOT NULL DEFAULT '' COMMENT '分组过滤',
+//`data_id_filter` varchar(255) NOT NULL DEFAULT '' COMMENT '配置ID过滤',
+//`events` varchar(64) NOT NULL DEFAULT '' COMMENT '订阅的事件类型',
+//`enabled` tinyint(1) COMMENT '是否启用',
+//`author` varchar(255) DEFAULT '' COMMENT '修改人',
+//`create_time` datetime NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+//`update_time` datetime NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
@@ -0,0 +1,60 @@
+package mw
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/utils"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gookit/slog"
+)
+
+// apiKeyScopeKey 使用 API Key 访问时，上下文中保存密钥的权限范围
+const apiKeyScopeKey = "api_key_scope"
+
+var ErrInvalidApiKey = errors.New("API Key 无效或已过期")
+
+// AuthenticateApiKey 校验 API Key，并将对应服务账号保存到上下文
+func AuthenticateApiKey(c *gin.Context, key string) error {
+	apiKey, err := dal.GetApiKeyByHash(utils.HashApiKey(key))
+	if err != nil {
+		return err
+	}
+	if apiKey == nil || apiKey.IsExpired() {
+		return ErrInvalidApiKey
+	}
+
+	user, err := dal.GetUserByID(int(apiKey.UserID))
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.IsServiceAccount {
+		return ErrInvalidApiKey
+	}
+	if !user.Enable {
+		return errors.New("服务账号已禁用")
+	}
+
+	if err := dal.TouchApiKey(apiKey, c.ClientIP()); err != nil {
+		slog.Warnf("记录API Key使用信息失败: %v", err)
+	}
+
+	c.Set("userid", int(user.ID))
+	c.Set("username", user.Username)
+	c.Set(apiKeyScopeKey, apiKey.Scope)
+	return nil
+}
+
+// ValidateNacosToken 校验 nacos 兼容接口的 accessToken，支持短时token和 API Key
+func ValidateNacosToken(c *gin.Context, token string) error {
+	if utils.IsApiKey(token) {
+		return AuthenticateApiKey(c, token)
+	}
+	return utils.ValidateShortTermToken(c, token)
+}
+
+// isReadOnlyApiKey 当前请求是否使用只读 API Key
+func isReadOnlyApiKey(c *gin.Context) bool {
+	return c.GetString(apiKeyScopeKey) == model.ApiKeyScopeRead
+}
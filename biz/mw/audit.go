@@ -0,0 +1,57 @@
+package mw
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/utils"
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gookit/slog"
+)
+
+// auditSummaryMaxLen 操作前后摘要的最大长度，超出部分截断
+const auditSummaryMaxLen = 2000
+
+// Audit 记录当前用户的一次变更操作，before/after 为操作前后的摘要，nil 表示没有
+// 摘要中不能包含配置内容、密码和密钥，记录失败只打印日志，不影响操作结果
+func Audit(c *gin.Context, targetType string, action string, target string, before interface{}, after interface{}) {
+	actor, _ := utils.GetUsernameFromContext(c)
+	log := &model.AuditLog{
+		Actor:      actor,
+		ClientIP:   c.ClientIP(),
+		TargetType: targetType,
+		Action:     action,
+		Target:     truncate(target, 512),
+		Before:     auditSummary(before),
+		After:      auditSummary(after),
+	}
+	if err := dal.CreateAuditLog(log); err != nil {
+		slog.Errorf("记录审计日志失败: %v", err)
+	}
+}
+
+func auditSummary(v interface{}) string {
+	var summary string
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		summary = value
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		summary = string(data)
+	}
+	return truncate(summary, auditSummaryMaxLen)
+}
+
+func truncate(s string, maxLen int) string {
+	r := []rune(s)
+	if len(r) <= maxLen {
+		return s
+	}
+	return string(r[:maxLen])
+}
@@ -1,7 +1,9 @@
 package mw
 
 import (
+	"confkeeper/biz/dal"
 	"confkeeper/utils"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -33,6 +35,20 @@ func JWTAuthMiddleware(isShortTerm ...bool) gin.HandlerFunc {
 			return
 		}
 
+		// API Key 不区分短时token，校验通过后直接放行
+		if utils.IsApiKey(token) {
+			if err := AuthenticateApiKey(c, token); err != nil {
+				c.JSON(http.StatusUnauthorized, map[string]interface{}{
+					"code": http.StatusUnauthorized,
+					"msg":  err.Error(),
+				})
+				c.Abort() // 终止后续处理
+				return
+			}
+			c.Next()
+			return
+		}
+
 		// 验证 token
 		claims, err := utils.ParseToken(token)
 		if err != nil {
@@ -44,17 +60,20 @@ func JWTAuthMiddleware(isShortTerm ...bool) gin.HandlerFunc {
 			return
 		}
 
-		// 检查短时token
+		// refresh token 和两步验证令牌只能用于登录流程
+		// 短时token只校验了密码，只能用于注册为短时token的接口，控制台接口只接受完成登录流程的 access token
+		tokenType, _ := claims["token_type"].(string)
+		wantType := "access"
 		if len(isShortTerm) > 0 && isShortTerm[0] {
-			tokenType, ok := claims["token_type"].(string)
-			if !ok || tokenType != "short_term" {
-				c.JSON(http.StatusUnauthorized, map[string]interface{}{
-					"code": http.StatusUnauthorized,
-					"msg":  "没有权限",
-				})
-				c.Abort() // 终止后续处理
-				return
-			}
+			wantType = "short_term"
+		}
+		if tokenType != wantType {
+			c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"code": http.StatusUnauthorized,
+				"msg":  "令牌类型错误，不能用于访问接口",
+			})
+			c.Abort() // 终止后续处理
+			return
 		}
 
 		// 将 claims 保存到上下文
@@ -68,3 +87,30 @@ func JWTAuthMiddleware(isShortTerm ...bool) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// Reauthenticate 重新校验请求的 token 或 API Key 以及用户状态，并刷新管理员检查的缓存
+// 用于长连接定期确认会话未过期、未吊销，用户未被禁用
+func Reauthenticate(c *gin.Context) error {
+	token := strings.TrimPrefix(c.Request.Header.Get("Authorization"), "Bearer ")
+	if utils.IsApiKey(token) {
+		if err := AuthenticateApiKey(c, token); err != nil {
+			return err
+		}
+	} else if _, err := utils.ParseToken(token); err != nil {
+		return err
+	}
+
+	userid, err := utils.GetUseridFromContext(c)
+	if err != nil {
+		return err
+	}
+	user, err := dal.GetUserByID(userid)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.Enable {
+		return errors.New("用户不存在或已禁用")
+	}
+	c.Set(isAdminKey, user.IsAdmin)
+	return nil
+}
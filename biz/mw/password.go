@@ -0,0 +1,95 @@
+package mw
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/utils"
+	"confkeeper/utils/config"
+	"confkeeper/utils/ldap_client"
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gookit/slog"
+)
+
+var (
+	// ErrWrongPassword 密码错误，调用方需要计入登录失败锁定
+	ErrWrongPassword = errors.New("密码错误")
+	// ErrUserSync 认证通过但同步用户信息失败
+	ErrUserSync = errors.New("用户同步失败")
+)
+
+// VerifyPassword 校验已存在用户的密码，所有使用密码认证的入口都应调用该方法
+// 启用 LDAP 时 LDAP 用户每次都到 LDAP 认证，通过后同步本地密码哈希和角色，认证失败返回的错误都包装了 ErrWrongPassword
+// 早期版本由 LDAP 登录自动创建的用户来源为 local，本地密码和 LDAP 密码同时校验通过时才改为 LDAP 用户，其余情况由管理员修改用户来源
+func VerifyPassword(c *gin.Context, user *model.User, plain string) error {
+	if !config.Cfg.Ldap.Enabled || user.IsServiceAccount || user.Source == model.UserSourceOidc ||
+		(user.Source == model.UserSourceLocal && user.Username == config.Cfg.Admin.Username) {
+		return verifyLocalPassword(user, plain)
+	}
+
+	if user.Source != model.UserSourceLdap {
+		if err := verifyLocalPassword(user, plain); err != nil {
+			return err
+		}
+		success, ldapUser, _ := ldap_client.LDAPAuth(user.Username, plain)
+		if !success {
+			// LDAP 中没有该用户、密码不同或连接失败，仍然是本地用户
+			return nil
+		}
+		if err := dal.UpdateUserSource(user, model.UserSourceLdap); err != nil {
+			return fmt.Errorf("%w: %v", ErrUserSync, err)
+		}
+		slog.Infof("用户 %s 的本地密码与 LDAP 密码一致，用户来源改为 LDAP", user.Username)
+		if err := SyncLdapRoles(c, user.Username, ldapUser.Groups); err != nil {
+			return fmt.Errorf("%w: 角色同步失败: %v", ErrUserSync, err)
+		}
+		return nil
+	}
+
+	success, ldapUser, err := ldap_client.LDAPAuth(user.Username, plain)
+	if !success {
+		if err == nil {
+			return ErrWrongPassword
+		}
+		return fmt.Errorf("%w: %v", ErrWrongPassword, err)
+	}
+
+	if err := dal.SyncUserPassword(user, plain); err != nil {
+		return fmt.Errorf("%w: %v", ErrUserSync, err)
+	}
+	if err := SyncLdapRoles(c, user.Username, ldapUser.Groups); err != nil {
+		return fmt.Errorf("%w: 角色同步失败: %v", ErrUserSync, err)
+	}
+	return nil
+}
+
+// SyncLdapRoles 按 LDAP 用户组同步角色，只处理 role_mapping 中配置的角色
+func SyncLdapRoles(c *gin.Context, username string, groups []string) error {
+	roles, managedRoles := utils.MappedRoles(config.Cfg.Ldap.RoleMapping, groups)
+	return SyncExternalRoles(c, username, roles, managedRoles)
+}
+
+// SyncExternalRoles 按外部身份源同步用户角色，角色有变化时以登录的用户为操作人记录审计日志
+func SyncExternalRoles(c *gin.Context, username string, roles []string, managedRoles []string) error {
+	if len(managedRoles) == 0 {
+		return nil
+	}
+	added, removed, err := dal.SyncUserRoles(username, roles, managedRoles)
+	if err != nil {
+		return err
+	}
+	if len(added) > 0 || len(removed) > 0 {
+		c.Set("username", username)
+		Audit(c, model.AuditTargetUser, "sync_roles", username, nil, map[string][]string{"added": added, "removed": removed})
+	}
+	return nil
+}
+
+func verifyLocalPassword(user *model.User, plain string) error {
+	if !dal.VerifyUserPassword(user, plain) {
+		return ErrWrongPassword
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package mw
 import (
 	"confkeeper/utils"
 	"errors"
+	"fmt"
 
 	"confkeeper/biz/dal"
 
@@ -11,6 +12,30 @@ import (
 
 var ErrUnauthorized = errors.New("unauthorized")
 
+// isAdminKey 同一请求内缓存管理员检查结果
+const isAdminKey = "is_admin"
+
+// IsAdmin 检查当前用户是否为管理员，不是管理员时返回错误
+func IsAdmin(c *gin.Context) error {
+	isAdmin, exists := c.Get(isAdminKey)
+	if !exists {
+		userid, err := utils.GetUseridFromContext(c)
+		if err != nil {
+			return err
+		}
+		isAdmin, err = dal.PermissionService.IsAdmin(userid)
+		if err != nil {
+			return err
+		}
+		c.Set(isAdminKey, isAdmin)
+	}
+
+	if !isAdmin.(bool) {
+		return fmt.Errorf("不是管理员，没有权限")
+	}
+	return nil
+}
+
 // CheckNamespaceReadOrWritePermissionHTTP 检查用户是否有命名空间的读取或读写权限
 func CheckNamespaceReadOrWritePermissionHTTP(c *gin.Context, namespace string) (bool, error) {
 	username, err := utils.GetUsernameFromContext(c)
@@ -42,5 +67,71 @@ func CheckNamespaceWritePermissionHTTP(c *gin.Context, namespace string) (bool,
 		return false, ErrUnauthorized
 	}
 
+	// 只读 API Key 没有写权限
+	if isReadOnlyApiKey(c) {
+		return false, nil
+	}
+
 	return dal.PermissionService.CheckNamespaceWritePermission(username, namespace)
 }
+
+// CheckConfigReadPermissionHTTP 检查用户是否有指定配置的读取或读写权限
+func CheckConfigReadPermissionHTTP(c *gin.Context, tenant string, group string, dataId string) (bool, error) {
+	username, err := utils.GetUsernameFromContext(c)
+	if err != nil || username == "" {
+		return false, ErrUnauthorized
+	}
+
+	return dal.PermissionService.CheckConfigReadPermission(username, tenant, group, dataId)
+}
+
+// CheckConfigWritePermissionHTTP 检查用户是否有指定配置的写权限
+func CheckConfigWritePermissionHTTP(c *gin.Context, tenant string, group string, dataId string) (bool, error) {
+	username, err := utils.GetUsernameFromContext(c)
+	if err != nil || username == "" {
+		return false, ErrUnauthorized
+	}
+
+	// 只读 API Key 没有写权限
+	if isReadOnlyApiKey(c) {
+		return false, nil
+	}
+
+	return dal.PermissionService.CheckConfigWritePermission(username, tenant, group, dataId)
+}
+
+// CheckConfigRangeWritePermissionHTTP 检查用户是否有 group、dataId 通配符范围内所有配置的写权限
+func CheckConfigRangeWritePermissionHTTP(c *gin.Context, tenant string, group string, dataId string) (bool, error) {
+	username, err := utils.GetUsernameFromContext(c)
+	if err != nil || username == "" {
+		return false, ErrUnauthorized
+	}
+
+	// 只读 API Key 没有写权限
+	if isReadOnlyApiKey(c) {
+		return false, nil
+	}
+
+	return dal.PermissionService.CheckConfigRangeWritePermission(username, tenant, group, dataId)
+}
+
+// NamespaceReadScopeHTTP 获取用户在命名空间内可以读取的范围，管理员返回 nil 表示不限制
+func NamespaceReadScopeHTTP(c *gin.Context, namespace string) (*dal.ResourceScope, error) {
+	if IsAdmin(c) == nil {
+		return nil, nil
+	}
+
+	username, err := utils.GetUsernameFromContext(c)
+	if err != nil || username == "" {
+		return nil, ErrUnauthorized
+	}
+
+	scope, err := dal.PermissionService.GetNamespaceReadScope(username, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if scope.IsEmpty() {
+		return nil, ErrUnauthorized
+	}
+	return scope, nil
+}
@@ -10,4 +10,7 @@ const (
 	Code_PasswordErr   Code = 502
 	Code_AlreadyExists Code = 503
 	Code_CaptchaErr    Code = 504
+	Code_Conflict      Code = 505
+	Code_ValidateErr   Code = 506
+	Code_TotpRequired  Code = 507
 )
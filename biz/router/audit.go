@@ -0,0 +1,16 @@
+package router
+
+import (
+	hAudit "confkeeper/biz/handler/audit"
+	"confkeeper/biz/mw"
+
+	"github.com/gin-gonic/gin"
+)
+
+func auditRoutes(apiGroup *gin.RouterGroup) {
+	auditGroup := apiGroup.Group("/audit")
+	auditGroup.Use(mw.JWTAuthMiddleware())
+	{
+		auditGroup.GET("/list", hAudit.AuditList)
+	}
+}
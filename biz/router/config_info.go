@@ -14,16 +14,25 @@ func configInfoRoutes(apiGroup *gin.RouterGroup) {
 		configGroup.DELETE("/delete/:config_id", mw.JWTAuthMiddleware(), hConfigInfo.DeleteConfig)
 		configGroup.DELETE("/batch_delete", mw.JWTAuthMiddleware(), hConfigInfo.BatchDeleteConfig)
 		configGroup.POST("/update/:config_id", mw.JWTAuthMiddleware(), hConfigInfo.UpdateConfig)
+		configGroup.POST("/rollback/:config_id", mw.JWTAuthMiddleware(), hConfigInfo.RollbackConfig)
 		configGroup.POST("/update_by_file", mw.JWTAuthMiddleware(true), hConfigInfo.UpdateConfigByFile)
 		configGroup.POST("/update_by_user", hConfigInfo.UpdateConfigByUser)
 		configGroup.GET("/list", mw.JWTAuthMiddleware(), hConfigInfo.ConfigList)
 		configGroup.GET("/get/:config_id", mw.JWTAuthMiddleware(), hConfigInfo.ConfigContent)
 		configGroup.GET("/get", mw.JWTAuthMiddleware(), hConfigInfo.ConfigContentByParams)
+		configGroup.GET("/watch", mw.JWTAuthMiddleware(), hConfigInfo.ConfigWatch)
 		configGroup.GET("/get_by_file", mw.JWTAuthMiddleware(true), hConfigInfo.GetConfigByFile)
 		configGroup.GET("/get_by_user", hConfigInfo.GetConfigByUser)
 		configGroup.GET("/get_version/:config_id", mw.JWTAuthMiddleware(), hConfigInfo.ConfigVersion)
+		configGroup.GET("/diff/:config_id", mw.JWTAuthMiddleware(), hConfigInfo.ConfigDiff)
 		configGroup.POST("/clone", mw.JWTAuthMiddleware(), hConfigInfo.ConfigClone)
 		configGroup.POST("/cleanup", mw.JWTAuthMiddleware(), hConfigInfo.ConfigCleanup)
+		configGroup.GET("/export", mw.JWTAuthMiddleware(), hConfigInfo.ConfigExport)
+		configGroup.POST("/import", mw.JWTAuthMiddleware(), hConfigInfo.ConfigImport)
+		configGroup.POST("/beta/publish/:config_id", mw.JWTAuthMiddleware(), hConfigInfo.PublishBetaConfig)
+		configGroup.POST("/beta/promote/:config_id", mw.JWTAuthMiddleware(), hConfigInfo.PromoteBetaConfig)
+		configGroup.POST("/beta/abort/:config_id", mw.JWTAuthMiddleware(), hConfigInfo.AbortBetaConfig)
+		configGroup.GET("/beta/get/:config_id", mw.JWTAuthMiddleware(), hConfigInfo.BetaConfigContent)
 		configGroup.GET("/language_list", hConfigInfo.ConfigLanguageList)
 	}
 }
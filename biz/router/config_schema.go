@@ -0,0 +1,20 @@
+package router
+
+import (
+	hConfigSchema "confkeeper/biz/handler/config_schema"
+	"confkeeper/biz/mw"
+
+	"github.com/gin-gonic/gin"
+)
+
+func configSchemaRoutes(apiGroup *gin.RouterGroup) {
+	schemaGroup := apiGroup.Group("/schema")
+	schemaGroup.Use(mw.JWTAuthMiddleware())
+	{
+		schemaGroup.PUT("/add", hConfigSchema.CreateSchema)
+		schemaGroup.POST("/update/:id", hConfigSchema.UpdateSchema)
+		schemaGroup.DELETE("/delete/:id", hConfigSchema.DeleteSchema)
+		schemaGroup.GET("/get", hConfigSchema.GetSchema)
+		schemaGroup.GET("/list", hConfigSchema.SchemaList)
+	}
+}
@@ -14,6 +14,7 @@ func permissionRoutes(apiGroup *gin.RouterGroup) {
 		permissionGroup.PUT("/add", hPermission.CreatePermission)
 		permissionGroup.DELETE("/delete", hPermission.DeletePermission)
 		permissionGroup.GET("/list", hPermission.PermissionList)
+		permissionGroup.POST("/explain", hPermission.ExplainPermission)
 	}
 
 }
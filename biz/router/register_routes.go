@@ -5,10 +5,14 @@ import "github.com/gin-gonic/gin"
 func RegisterRoutes(r *gin.Engine) {
 	apiGroup := r.Group("/api")
 	diyRoutes(apiGroup)
+	auditRoutes(apiGroup)
 	configInfoRoutes(apiGroup)
+	configSchemaRoutes(apiGroup)
 	permissionRoutes(apiGroup)
 	roleRoutes(apiGroup)
+	serviceAccountRoutes(apiGroup)
 	tenantRoutes(apiGroup)
 	userRoutes(apiGroup)
+	webhookRoutes(apiGroup)
 	nacosRoutes(r)
 }
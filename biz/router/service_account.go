@@ -0,0 +1,20 @@
+package router
+
+import (
+	hServiceAccount "confkeeper/biz/handler/service_account"
+	"confkeeper/biz/mw"
+
+	"github.com/gin-gonic/gin"
+)
+
+func serviceAccountRoutes(apiGroup *gin.RouterGroup) {
+	serviceAccountGroup := apiGroup.Group("/service_account")
+	serviceAccountGroup.Use(mw.JWTAuthMiddleware())
+	{
+		serviceAccountGroup.PUT("/add", hServiceAccount.CreateServiceAccount)
+		serviceAccountGroup.GET("/list", hServiceAccount.ServiceAccountList)
+		serviceAccountGroup.PUT("/key/add/:user_id", hServiceAccount.CreateApiKey)
+		serviceAccountGroup.GET("/key/list/:user_id", hServiceAccount.ApiKeyList)
+		serviceAccountGroup.DELETE("/key/delete/:key_id", hServiceAccount.DeleteApiKey)
+	}
+}
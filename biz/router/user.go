@@ -16,6 +16,21 @@ func userRoutes(apiGroup *gin.RouterGroup) {
 		userGroup.POST("/change_passwd/:user_id", mw.JWTAuthMiddleware(), hUser.ChangePasswd)
 		userGroup.GET("/captcha", hUser.GenerateCaptcha)
 		userGroup.POST("/login", hUser.UserLogin)
+		userGroup.POST("/login/totp", hUser.UserLoginTotp)
+		userGroup.POST("/login/totp/enroll", hUser.UserLoginTotpEnroll)
+		userGroup.POST("/refresh", hUser.RefreshToken)
+		userGroup.GET("/oidc/login", hUser.OidcLogin)
+		userGroup.GET("/oidc/callback", hUser.OidcCallback)
+		userGroup.POST("/ldap/check", mw.JWTAuthMiddleware(), hUser.LdapCheck)
+		userGroup.POST("/logout", mw.JWTAuthMiddleware(), hUser.UserLogout)
+		userGroup.POST("/revoke/:user_id", mw.JWTAuthMiddleware(), hUser.RevokeSessions)
+		userGroup.POST("/unlock/:user_id", mw.JWTAuthMiddleware(), hUser.UnlockUser)
+		userGroup.GET("/totp/status", mw.JWTAuthMiddleware(), hUser.TotpStatus)
+		userGroup.POST("/totp/enroll", mw.JWTAuthMiddleware(), hUser.TotpEnroll)
+		userGroup.POST("/totp/verify", mw.JWTAuthMiddleware(), hUser.TotpVerify)
+		userGroup.POST("/totp/recovery_codes", mw.JWTAuthMiddleware(), hUser.TotpRecoveryCodes)
+		userGroup.POST("/totp/disable/:user_id", mw.JWTAuthMiddleware(), hUser.TotpDisable)
+		userGroup.POST("/totp/require", mw.JWTAuthMiddleware(), hUser.TotpRequire)
 		userGroup.GET("/list", mw.JWTAuthMiddleware(), hUser.UserList)
 		userGroup.GET("/info/:user_id", mw.JWTAuthMiddleware(), hUser.UserInfo)
 	}
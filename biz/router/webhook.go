@@ -0,0 +1,21 @@
+package router
+
+import (
+	hWebhook "confkeeper/biz/handler/webhook"
+	"confkeeper/biz/mw"
+
+	"github.com/gin-gonic/gin"
+)
+
+func webhookRoutes(apiGroup *gin.RouterGroup) {
+	webhookGroup := apiGroup.Group("/webhook")
+	webhookGroup.Use(mw.JWTAuthMiddleware())
+	{
+		webhookGroup.PUT("/add", hWebhook.CreateWebhook)
+		webhookGroup.POST("/update/:id", hWebhook.UpdateWebhook)
+		webhookGroup.DELETE("/delete/:id", hWebhook.DeleteWebhook)
+		webhookGroup.GET("/list", hWebhook.WebhookList)
+		webhookGroup.POST("/test/:id", hWebhook.TestWebhook)
+		webhookGroup.GET("/delivery/list/:id", hWebhook.DeliveryList)
+	}
+}
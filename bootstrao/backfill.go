@@ -0,0 +1,60 @@
+package bootstrao
+
+import (
+	"confkeeper/biz/model"
+	"confkeeper/utils"
+
+	"github.com/gookit/slog"
+	"gorm.io/gorm"
+)
+
+// BackfillConfigDigest 为历史配置版本补齐内容摘要
+func BackfillConfigDigest(db *gorm.DB) error {
+	var configInfos []*model.ConfigInfo
+	var total int
+
+	result := db.Where("md5 = '' OR md5 IS NULL OR sha256 = '' OR sha256 IS NULL").
+		FindInBatches(&configInfos, 200, func(tx *gorm.DB, batch int) error {
+			for _, info := range configInfos {
+				err := tx.Model(&model.ConfigInfo{}).Where("id = ?", info.ID).Updates(map[string]interface{}{
+					"md5":    utils.MD5(info.Content),
+					"sha256": utils.SHA256(info.Content),
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+			total += len(configInfos)
+			return nil
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if total > 0 {
+		slog.Infof("补齐配置内容摘要成功，共%d条", total)
+	}
+	return nil
+}
+
+// BackfillAdminUser 升级前的管理员由用户ID判断，没有任何管理员时将ID为1的用户设为管理员
+func BackfillAdminUser(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&model.User{}).Where("is_admin = ?", true).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var user model.User
+	result := db.Where("id = ?", 1).Limit(1).Find(&user)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+	if err := db.Model(&user).Update("is_admin", true).Error; err != nil {
+		return err
+	}
+	slog.Infof("设置管理员用户成功: %s", user.Username)
+	return nil
+}
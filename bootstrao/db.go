@@ -14,10 +14,31 @@ func Migrate(db *gorm.DB) error {
 		&model.TenantInfo{},
 		&model.Roles{},
 		&model.Permissions{},
+		&model.ConfigSchema{},
+		&model.ConfigInfoBeta{},
+		&model.ApiKey{},
+		&model.UserSession{},
+		&model.RevokedToken{},
+		&model.RefreshToken{},
+		&model.UserRecoveryCode{},
+		&model.Setting{},
+		&model.AuditLog{},
+		&model.Webhook{},
+		&model.WebhookDelivery{},
 	); err != nil {
 		return err
 	}
 
+	// 补齐历史版本的内容摘要
+	if err := BackfillConfigDigest(db); err != nil {
+		return err
+	}
+
+	// 先为升级前的管理员补齐管理员标记，避免初始化数据时重复创建管理员
+	if err := BackfillAdminUser(db); err != nil {
+		return err
+	}
+
 	err := InitData(db)
 	if err != nil {
 		return err
@@ -2,19 +2,35 @@ package bootstrao
 
 import (
 	"confkeeper/biz/model"
-	"confkeeper/utils"
 	"confkeeper/utils/config"
+	"confkeeper/utils/password"
 
 	"github.com/gookit/slog"
 	"gorm.io/gorm"
 )
 
 func InitData(db *gorm.DB) error {
+	// 已有管理员时不再创建，管理员可能已改名
+	var adminCount int64
+	if err := db.Model(&model.User{}).Where("is_admin = ?", true).Count(&adminCount).Error; err != nil {
+		return err
+	}
+	if adminCount > 0 {
+		slog.Infof("管理员用户已存在")
+		return nil
+	}
+
+	hashedPassword, err := password.Hash(config.Cfg.Admin.Password)
+	if err != nil {
+		return err
+	}
+
 	// 创建管理员用户
 	adminUser := &model.User{
 		Username: config.Cfg.Admin.Username,
-		Password: utils.MD5(config.Cfg.Admin.Password),
+		Password: hashedPassword,
 		Enable:   true,
+		IsAdmin:  true,
 	}
 
 	userResult := db.Where(model.User{Username: config.Cfg.Admin.Username}).FirstOrCreate(adminUser)
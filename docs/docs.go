@@ -19,6 +19,92 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/audit/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按操作人、IP、操作对象、操作和时间范围分页查询审计日志，按时间倒序",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "审计日志"
+                ],
+                "summary": "审计日志列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作人",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端IP",
+                        "name": "client_ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作对象类型: config / config_beta / schema / tenant / user / role / permission / service_account / api_key / webhook / setting",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作对象，模糊查询",
+                        "name": "target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间，格式 2006-01-02 15:04:05",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间，格式 2006-01-02 15:04:05",
+                        "name": "end_time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/audit.ListResp"
+                        }
+                    }
+                }
+            }
+        },
         "/api/config/add": {
             "put": {
                 "security": [
@@ -97,6 +183,163 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/config/beta/abort/{config_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除灰度内容，灰度客户端回到正式版本",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "终止灰度",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "配置ID",
+                        "name": "config_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/config/beta/get/{config_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取配置当前的灰度内容及规则，没有灰度时 data 为空",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "获取灰度配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "配置ID",
+                        "name": "config_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/config_info.BetaContentResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/config/beta/promote/{config_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将灰度内容发布为新的最大版本并结束灰度，所有客户端获取新版本",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "灰度转正",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "配置ID",
+                        "name": "config_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/config/beta/publish/{config_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "灰度内容与正式版本分开存储，只有客户端IP(支持CIDR)或 X-Config-Labels 请求头命中规则的请求方获取灰度内容，部署在反向代理后时需要配置 server.trusted_proxies 才能获取真实的客户端IP，重复发布时覆盖原灰度",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "灰度发布配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "配置ID",
+                        "name": "config_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "灰度内容及规则",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/config_info.PublishBetaReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
         "/api/config/cleanup": {
             "post": {
                 "security": [
@@ -225,9 +468,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/config/get": {
+        "/api/config/diff/{config_id}": {
             "get": {
-                "description": "通过tenant、dataId、group参数获取配置内容",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返回两个版本之间的统一格式差异，json、yaml、toml、properties类型额外返回键级别差异(新增、删除、修改)",
                 "consumes": [
                     "application/json"
                 ],
@@ -237,66 +485,148 @@ const docTemplate = `{
                 "tags": [
                     "配置"
                 ],
-                "summary": "通过参数获取配置内容",
+                "summary": "比较配置的两个版本",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "租户ID",
-                        "name": "tenant_id",
-                        "in": "query",
+                        "description": "配置ID",
+                        "name": "config_id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "数据ID",
-                        "name": "data_id",
+                        "type": "integer",
+                        "description": "旧版本",
+                        "name": "from_version",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "分组ID",
-                        "name": "group_id",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "新版本，默认为最新版本",
+                        "name": "to_version",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/config_info.ContentByParamsResp"
-                        }
-                    },
-                    "400": {
-                        "description": "参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/config_info.ContentByParamsResp"
-                        }
-                    },
-                    "404": {
-                        "description": "配置不存在",
-                        "schema": {
-                            "$ref": "#/definitions/config_info.ContentByParamsResp"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器错误",
-                        "schema": {
-                            "$ref": "#/definitions/config_info.ContentByParamsResp"
+                            "$ref": "#/definitions/config_info.DiffResp"
                         }
                     }
                 }
             }
         },
-        "/api/config/get/{config_id}": {
+        "/api/config/export": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "获取配置详情",
+                "description": "将命名空间下每个配置的最新版本导出为 nacos v2 格式的 ZIP(group/dataId 文件 + .metadata.yml)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "导出命名空间配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "命名空间id",
+                        "name": "tenant_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "配置压缩包",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/config/get": {
+            "get": {
+                "description": "通过tenant、dataId、group参数获取配置内容",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "通过参数获取配置内容",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "租户ID",
+                        "name": "tenant_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "数据ID",
+                        "name": "data_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "分组ID",
+                        "name": "group_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/config_info.ContentByParamsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/config_info.ContentByParamsResp"
+                        }
+                    },
+                    "404": {
+                        "description": "配置不存在",
+                        "schema": {
+                            "$ref": "#/definitions/config_info.ContentByParamsResp"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/config_info.ContentByParamsResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/config/get/{config_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取配置详情",
                 "consumes": [
                     "application/json"
                 ],
@@ -360,6 +690,12 @@ const docTemplate = `{
                         "name": "group",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端标签(逗号分隔的key=value)，用于匹配灰度规则",
+                        "name": "X-Config-Labels",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -386,7 +722,7 @@ const docTemplate = `{
         },
         "/api/config/get_by_user": {
             "get": {
-                "description": "直接使用账号获取配置",
+                "description": "直接使用账号获取配置，用户启用了两步验证时需要同时提交验证码，自动化场景建议使用 API Key",
                 "consumes": [
                     "application/json"
                 ],
@@ -412,6 +748,12 @@ const docTemplate = `{
                         "in": "query",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "两步验证码，用户启用了两步验证时必填",
+                        "name": "totp_code",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "租户ID",
@@ -432,6 +774,12 @@ const docTemplate = `{
                         "name": "group",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端标签(逗号分隔的key=value)，用于匹配灰度规则",
+                        "name": "X-Config-Labels",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -506,6 +854,68 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/config/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "导入 nacos v1/v2 格式的配置压缩包。冲突策略：ABORT(默认，存在冲突时不导入任何配置)、SKIP(跳过已存在的配置)、OVERWRITE(已存在的配置发布为新版本)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "导入命名空间配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "命名空间id",
+                        "name": "tenant_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ABORT",
+                            "SKIP",
+                            "OVERWRITE"
+                        ],
+                        "type": "string",
+                        "default": "ABORT",
+                        "description": "冲突策略",
+                        "name": "policy",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "跳过内容格式及Schema校验(仅管理员)",
+                        "name": "force",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "配置压缩包",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/config_info.ImportResp"
+                        }
+                    }
+                }
+            }
+        },
         "/api/config/language_list": {
             "get": {
                 "security": [
@@ -602,6 +1012,52 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/config/rollback/{config_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将指定历史版本的内容发布为新的最大版本，历史版本保持不变",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "回滚配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "配置ID",
+                        "name": "config_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "目标版本",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/config_info.RollbackReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
         "/api/config/update/{config_id}": {
             "post": {
                 "security": [
@@ -696,6 +1152,24 @@ const docTemplate = `{
                         "name": "content",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "期望的当前最新版本号(0表示配置不存在)",
+                        "name": "baseVersion",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期望的当前最新内容md5",
+                        "name": "casMd5",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "跳过内容格式及Schema校验(仅管理员)",
+                        "name": "force",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -710,7 +1184,7 @@ const docTemplate = `{
         },
         "/api/config/update_by_user": {
             "post": {
-                "description": "使用账号更新/创建配置",
+                "description": "使用账号更新/创建配置，用户启用了两步验证时需要同时提交验证码",
                 "consumes": [
                     "application/x-www-form-urlencoded"
                 ],
@@ -763,6 +1237,18 @@ const docTemplate = `{
                         "name": "content",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "跳过内容格式及Schema校验(仅管理员)",
+                        "name": "force",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "两步验证码，用户启用了两步验证时必填",
+                        "name": "totp_code",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -775,6 +1261,59 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/config/watch": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "使用 SSE 推送配置变更，请求头带 Upgrade: websocket 时使用 WebSocket，每条消息为一个 WatchEventData\nkey 格式为 tenant:group:dataId[@版本号或md5]，可以传多个，已知的版本或md5与当前不一致时建立连接后立即推送 stale 事件\n之后有变更时推送 publish / delete / beta 事件，需要每个配置的读取权限\n连接期间每次心跳重新校验登录状态和读取权限，失败时关闭连接",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "监听配置变更",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "监听的配置",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端标签(逗号分隔的key=value)，用于匹配灰度规则",
+                        "name": "X-Config-Labels",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/config_info.WatchEventData"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/api/is_demo": {
             "get": {
                 "description": "获取demo",
@@ -807,7 +1346,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "为角色分配权限",
+                "description": "为角色分配权限，资源格式为 tenant:group:dataId，支持通配符 * 和 ?；effect 为 deny 时为拒绝规则，优先于允许规则",
                 "consumes": [
                     "application/json"
                 ],
@@ -890,14 +1429,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/permission/list": {
-            "get": {
+        "/api/permission/explain": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "获取角色的权限列表",
+                "description": "判定用户对某个配置是否有指定操作的权限，并返回匹配的允许规则和拒绝规则",
                 "consumes": [
                     "application/json"
                 ],
@@ -907,19 +1446,58 @@ const docTemplate = `{
                 "tags": [
                     "权限管理"
                 ],
-                "summary": "权限列表",
+                "summary": "权限判定说明",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "角色名",
-                        "name": "role",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "页码",
+                        "description": "用户、配置和操作",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/permission.ExplainReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/permission.ExplainResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/permission/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取角色的权限列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限管理"
+                ],
+                "summary": "权限列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色名",
+                        "name": "role",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
                         "name": "page",
                         "in": "query"
                     },
@@ -1077,9 +1655,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/server_info": {
-            "get": {
-                "description": "服务信息",
+        "/api/schema/add": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "为 dataId/group/tenant 绑定 JSON Schema，发布 json/yaml/toml 配置时按该 schema 校验",
                 "consumes": [
                     "application/json"
                 ],
@@ -1087,20 +1670,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "测试"
+                    "配置Schema"
                 ],
-                "summary": "服务信息",
-                "responses": {}
+                "summary": "创建配置Schema",
+                "parameters": [
+                    {
+                        "description": "Schema信息",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/config_schema.CreateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
             }
         },
-        "/api/tenant/add": {
-            "put": {
+        "/api/schema/delete/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "创建命名空间",
+                "description": "删除配置Schema，删除后发布不再校验结构",
                 "consumes": [
                     "application/json"
                 ],
@@ -1108,18 +1709,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "命名空间"
+                    "配置Schema"
                 ],
-                "summary": "创建命名空间",
+                "summary": "删除配置Schema",
                 "parameters": [
                     {
-                        "description": "创建命名空间请求参数",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/tenant.CreateReq"
-                        }
+                        "type": "integer",
+                        "description": "SchemaID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1132,14 +1731,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tenant/delete/{id}": {
-            "delete": {
+        "/api/schema/get": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "删除命名空间",
+                "description": "根据 dataId/group/tenant 获取绑定的 JSON Schema",
                 "consumes": [
                     "application/json"
                 ],
@@ -1147,15 +1746,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "命名空间"
+                    "配置Schema"
                 ],
-                "summary": "删除命名空间",
+                "summary": "获取配置绑定的Schema",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "命名空间ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "配置id",
+                        "name": "data_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "组id",
+                        "name": "group_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间id",
+                        "name": "tenant_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -1163,20 +1776,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CommonResp"
+                            "$ref": "#/definitions/config_schema.GetResp"
                         }
                     }
                 }
             }
         },
-        "/api/tenant/list": {
+        "/api/schema/list": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "命名空间列表",
+                "description": "配置Schema列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -1184,9 +1797,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "命名空间"
+                    "配置Schema"
                 ],
-                "summary": "命名空间列表",
+                "summary": "配置Schema列表",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1201,26 +1814,45 @@ const docTemplate = `{
                         "description": "每页数量",
                         "name": "page_size",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间id",
+                        "name": "tenant_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "配置id",
+                        "name": "data_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "组id",
+                        "name": "group_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tenant.ListResp"
+                            "$ref": "#/definitions/config_schema.ListResp"
                         }
                     }
                 }
             }
         },
-        "/api/user/add": {
-            "put": {
+        "/api/schema/update/{id}": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "创建用户",
+                "description": "更新配置Schema，已发布的配置不会重新校验",
                 "consumes": [
                     "application/json"
                 ],
@@ -1228,17 +1860,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户"
+                    "配置Schema"
                 ],
-                "summary": "创建用户",
+                "summary": "更新配置Schema",
                 "parameters": [
                     {
-                        "description": "用户信息",
+                        "type": "integer",
+                        "description": "SchemaID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schema内容",
                         "name": "req",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.CreateReq"
+                            "$ref": "#/definitions/config_schema.UpdateReq"
                         }
                     }
                 ],
@@ -1252,34 +1891,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/captcha": {
+        "/api/server_info": {
             "get": {
-                "description": "生成登录验证码",
+                "description": "服务信息",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "用户"
+                    "测试"
+                ],
+                "summary": "服务信息",
+                "responses": {}
+            }
+        },
+        "/api/service_account/add": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建服务账号，服务账号不能通过密码登录，通过 API Key 访问，权限通过角色分配",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务账号"
+                ],
+                "summary": "创建服务账号",
+                "parameters": [
+                    {
+                        "description": "服务账号信息",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service_account.CreateReq"
+                        }
+                    }
                 ],
-                "summary": "生成验证码",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/user.CaptchaResp"
+                            "$ref": "#/definitions/response.CommonResp"
                         }
                     }
                 }
             }
         },
-        "/api/user/change_passwd/{user_id}": {
-            "post": {
+        "/api/service_account/key/add/{user_id}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "修改用户密码",
+                "description": "为服务账号创建 API Key，明文只在创建时返回一次。API Key 可作为 Authorization 请求头或 nacos 接口的 accessToken 使用",
                 "consumes": [
                     "application/json"
                 ],
@@ -1287,24 +1961,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户"
+                    "服务账号"
                 ],
-                "summary": "修改用户密码",
+                "summary": "创建API Key",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "用户ID",
+                        "type": "integer",
+                        "description": "服务账号ID",
                         "name": "user_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "密码信息",
+                        "description": "API Key信息",
                         "name": "req",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.ChangePasswdReq"
+                            "$ref": "#/definitions/service_account.CreateKeyReq"
                         }
                     }
                 ],
@@ -1312,20 +1986,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CommonResp"
+                            "$ref": "#/definitions/service_account.CreateKeyResp"
                         }
                     }
                 }
             }
         },
-        "/api/user/delete/{user_id}": {
+        "/api/service_account/key/delete/{key_id}": {
             "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "删除用户",
+                "description": "吊销API Key，吊销后立即失效",
                 "consumes": [
                     "application/json"
                 ],
@@ -1333,14 +2007,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户"
+                    "服务账号"
                 ],
-                "summary": "删除用户",
+                "summary": "吊销API Key",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "用户ID",
-                        "name": "user_id",
+                        "type": "integer",
+                        "description": "API Key ID",
+                        "name": "key_id",
                         "in": "path",
                         "required": true
                     }
@@ -1355,14 +2029,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/info/{user_id}": {
+        "/api/service_account/key/list/{user_id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "用户信息",
+                "description": "获取服务账号的 API Key 列表，包括最后使用时间和IP，不返回密钥明文",
                 "consumes": [
                     "application/json"
                 ],
@@ -1370,13 +2044,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户"
+                    "服务账号"
                 ],
-                "summary": "用户信息",
+                "summary": "API Key列表",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "用户ID",
+                        "type": "integer",
+                        "description": "服务账号ID",
                         "name": "user_id",
                         "in": "path",
                         "required": true
@@ -1386,20 +2060,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/user.InfoResp"
+                            "$ref": "#/definitions/service_account.KeyListResp"
                         }
                     }
                 }
             }
         },
-        "/api/user/list": {
+        "/api/service_account/list": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "用户列表",
+                "description": "服务账号列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -1407,9 +2081,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户"
+                    "服务账号"
                 ],
-                "summary": "用户列表",
+                "summary": "服务账号列表",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1430,27 +2104,26 @@ const docTemplate = `{
                         "description": "用户名",
                         "name": "username",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "邮箱",
-                        "name": "email",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/user.ListResp"
+                            "$ref": "#/definitions/service_account.ListResp"
                         }
                     }
                 }
             }
         },
-        "/api/user/login": {
-            "post": {
-                "description": "用户登录",
+        "/api/tenant/add": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建命名空间",
                 "consumes": [
                     "application/json"
                 ],
@@ -1458,17 +2131,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户"
+                    "命名空间"
                 ],
-                "summary": "用户登录",
+                "summary": "创建命名空间",
                 "parameters": [
                     {
-                        "description": "登录凭证",
-                        "name": "req",
+                        "description": "创建命名空间请求参数",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.LoginReq"
+                            "$ref": "#/definitions/tenant.CreateReq"
                         }
                     }
                 ],
@@ -1476,20 +2149,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/user.LoginResp"
+                            "$ref": "#/definitions/response.CommonResp"
                         }
                     }
                 }
             }
         },
-        "/api/user/update/{user_id}": {
-            "post": {
+        "/api/tenant/delete/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "更新用户",
+                "description": "删除命名空间",
                 "consumes": [
                     "application/json"
                 ],
@@ -1497,40 +2170,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户"
+                    "命名空间"
                 ],
-                "summary": "更新用户",
+                "summary": "删除命名空间",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "用户ID",
-                        "name": "user_id",
+                        "description": "命名空间ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "更新信息",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/user.UpdateReq"
+                            "$ref": "#/definitions/response.CommonResp"
                         }
                     }
+                }
+            }
+        },
+        "/api/tenant/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "命名空间列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "命名空间"
+                ],
+                "summary": "命名空间列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CommonResp"
+                            "$ref": "#/definitions/tenant.ListResp"
                         }
                     }
                 }
             }
         },
-        "/nacos/v1/auth/login": {
-            "post": {
-                "description": "用户登录(nacos兼容)",
+        "/api/user/add": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建用户",
                 "consumes": [
                     "application/json"
                 ],
@@ -1538,18 +2251,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户",
-                    "nacos兼容"
+                    "用户"
                 ],
-                "summary": "用户登录(nacos兼容)",
+                "summary": "创建用户",
                 "parameters": [
                     {
-                        "description": "登录凭证",
+                        "description": "用户信息",
                         "name": "req",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.NacosLoginReq"
+                            "$ref": "#/definitions/user.CreateReq"
                         }
                     }
                 ],
@@ -1557,138 +2269,102 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/user.NacosLoginResp"
+                            "$ref": "#/definitions/response.CommonResp"
                         }
                     }
                 }
             }
         },
-        "/nacos/v1/cs/configs": {
+        "/api/user/captcha": {
             "get": {
-                "description": "获取配置(nacos兼容)",
+                "description": "生成登录验证码",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "生成验证码",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.CaptchaResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/change_passwd/{user_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "修改用户密码",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "配置",
-                    "nacos兼容"
+                    "用户"
                 ],
-                "summary": "获取配置(nacos兼容)",
+                "summary": "修改用户密码",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "token",
-                        "name": "accessToken",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "tenant",
-                        "name": "tenant",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "dataId",
-                        "name": "dataId",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "group",
-                        "name": "group",
-                        "in": "query",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "tenant",
-                        "name": "tenant",
-                        "in": "query",
-                        "required": true
+                        "description": "密码信息",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.ChangePasswdReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "配置不存在",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器错误",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/response.CommonResp"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "更新/创建配置(nacos兼容)",
+            }
+        },
+        "/api/user/delete/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除用户",
                 "consumes": [
-                    "application/x-www-form-urlencoded"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "配置",
-                    "nacos兼容"
+                    "用户"
                 ],
-                "summary": "更新/创建配置(nacos兼容)",
+                "summary": "删除用户",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "token",
-                        "name": "accessToken",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "tenant",
-                        "name": "tenant",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "dataId",
-                        "name": "dataId",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "group",
-                        "name": "group",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "type",
-                        "name": "type",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "content",
-                        "name": "content",
-                        "in": "formData",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -1701,134 +2377,2542 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "config_info.BatchDeleteReq": {
-            "type": "object",
-            "required": [
-                "config_ids"
-            ],
-            "properties": {
-                "config_ids": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
-                }
-            }
+        },
+        "/api/user/info/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "用户信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "用户信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.InfoResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/ldap/check": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "使用当前配置连接LDAP服务器并绑定服务账号，指定用户名时搜索该用户及其所在的组，同时指定密码时校验用户密码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "检查LDAP配置",
+                "parameters": [
+                    {
+                        "description": "待检查的用户",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.LdapCheckReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.LdapCheckResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "用户列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "用户列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户名",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "邮箱",
+                        "name": "email",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.ListResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/login": {
+            "post": {
+                "description": "用户登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "用户登录",
+                "parameters": [
+                    {
+                        "description": "登录凭证",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.LoginReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.LoginResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/login/totp": {
+            "post": {
+                "description": "密码校验通过后提交验证码或恢复码完成登录。尚未绑定的用户需要先调用 /api/user/login/totp/enroll，校验通过后同时返回恢复码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "两步验证登录",
+                "parameters": [
+                    {
+                        "description": "两步验证",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.LoginTotpReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.LoginResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/login/totp/enroll": {
+            "post": {
+                "description": "管理员要求启用两步验证时，尚未绑定的用户在密码校验通过后获取TOTP密钥，再调用 /api/user/login/totp 完成绑定和登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "登录时绑定两步验证",
+                "parameters": [
+                    {
+                        "description": "两步验证令牌",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.LoginTotpEnrollReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.TotpEnrollResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "吊销当前使用的token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "退出登录",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/oidc/callback": {
+            "get": {
+                "description": "身份提供方登录完成后的回调，校验 ID Token 后自动创建用户、按用户组同步角色并签发token。配置了 success_url 时通过 URL fragment 跳转回前端，否则直接返回 JSON",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "OIDC登录回调",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "授权码",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "state",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.LoginResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/oidc/login": {
+            "get": {
+                "description": "跳转到身份提供方登录，使用授权码 + PKCE 流程，登录完成后回调 /api/user/oidc/callback",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "OIDC单点登录",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "记住我",
+                        "name": "remember_me",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "跳转到身份提供方",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/refresh": {
+            "post": {
+                "description": "使用refresh token换取新的token和refresh token，旧的refresh token随即失效，重复使用会吊销整个会话",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "刷新token",
+                "parameters": [
+                    {
+                        "description": "refresh token",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.RefreshTokenReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.LoginResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/revoke/{user_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "吊销用户已签发的所有token，用户需要重新登录。非管理员只能吊销自己的会话",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "吊销用户所有会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/totp/disable/{user_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "停用自己的两步验证需要提供验证码或恢复码；管理员可以直接停用其他用户的两步验证(如用户丢失设备)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "停用两步验证",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "验证码",
+                        "name": "req",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/user.TotpDisableBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/totp/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "生成TOTP密钥，使用验证器应用扫码后调用 /api/user/totp/verify 校验验证码完成绑定",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "绑定两步验证",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.TotpEnrollResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/totp/recovery_codes": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "校验验证码后重新生成恢复码，旧的恢复码全部失效，新的恢复码只返回一次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "重新生成恢复码",
+                "parameters": [
+                    {
+                        "description": "验证码",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.TotpCodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.TotpRecoveryCodesResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/totp/require": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "开启后所有用户登录控制台时都需要两步验证，未绑定的用户在登录时完成绑定。API Key 和 Nacos 登录不受影响",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "要求启用两步验证",
+                "parameters": [
+                    {
+                        "description": "是否要求",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.TotpRequireReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/totp/status": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取当前用户的两步验证状态",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "两步验证状态",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.TotpStatusResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/totp/verify": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "校验验证器应用生成的验证码，通过后启用两步验证并返回恢复码，恢复码只返回一次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "完成两步验证绑定",
+                "parameters": [
+                    {
+                        "description": "验证码",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.TotpCodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.TotpRecoveryCodesResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/unlock/{user_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "解除用户因登录失败次数过多导致的锁定，并清零连续失败次数",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "解除用户锁定",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/update/{user_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新用户",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "更新用户",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新信息",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.UpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhook/add": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "订阅命名空间的配置变更，需要对过滤范围内所有配置有写权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "创建Webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook信息",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.CreateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhook/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除Webhook及其投递记录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "删除Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "WebhookID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhook/delivery/list/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Webhook的投递记录，按时间倒序，需要订阅范围内所有配置的读取权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Webhook投递记录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "WebhookID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.DeliveryListResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhook/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "命名空间下的Webhook列表，只返回订阅范围内所有配置都有读取权限的Webhook",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Webhook列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间ID",
+                        "name": "tenant_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.ListResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhook/test/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "立即发送一次 test 事件，不重试，返回本次投递的结果",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "测试Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "WebhookID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.TestResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhook/update/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新Webhook，修改后的过滤范围同样需要写权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "更新Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "WebhookID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook信息",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.UpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/nacos/v1/auth/login": {
+            "post": {
+                "description": "用户登录(nacos兼容)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户",
+                    "nacos兼容"
+                ],
+                "summary": "用户登录(nacos兼容)",
+                "parameters": [
+                    {
+                        "description": "登录凭证",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.NacosLoginReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.NacosLoginResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/nacos/v1/cs/configs": {
+            "get": {
+                "description": "获取配置(nacos兼容)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "配置",
+                    "nacos兼容"
+                ],
+                "summary": "获取配置(nacos兼容)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "accessToken",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tenant",
+                        "name": "tenant",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "dataId",
+                        "name": "dataId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "group",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tenant",
+                        "name": "tenant",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端标签(逗号分隔的key=value)，用于匹配灰度规则",
+                        "name": "X-Config-Labels",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "配置不存在",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "更新/创建配置(nacos兼容)",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置",
+                    "nacos兼容"
+                ],
+                "summary": "更新/创建配置(nacos兼容)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "accessToken",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tenant",
+                        "name": "tenant",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "dataId",
+                        "name": "dataId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "group",
+                        "name": "group",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "type",
+                        "name": "type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "content",
+                        "name": "content",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "期望的当前最新版本号(0表示配置不存在)",
+                        "name": "baseVersion",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期望的当前最新内容md5",
+                        "name": "casMd5",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "跳过内容格式及Schema校验(仅管理员)",
+                        "name": "force",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommonResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/nacos/v1/cs/configs/listener": {
+            "post": {
+                "description": "长轮询监听配置变更，Listening-Configs格式为 dataId^2group^2md5^2tenant^1，有变更时立即返回变更的配置键",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "配置",
+                    "nacos兼容"
+                ],
+                "summary": "监听配置变更(nacos兼容)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "accessToken",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "监听的配置",
+                        "name": "Listening-Configs",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30000,
+                        "description": "长轮询超时时间(毫秒)",
+                        "name": "Long-Pulling-Timeout",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "没有变更时是否立即返回",
+                        "name": "Long-Pulling-Timeout-No-Hangup",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端标签(逗号分隔的key=value)，用于匹配灰度规则",
+                        "name": "X-Config-Labels",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "变更的配置键",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "没有权限",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "audit.ListData": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "after": {
+                    "description": "操作后摘要",
+                    "type": "string"
+                },
+                "before": {
+                    "description": "操作前摘要",
+                    "type": "string"
+                },
+                "client_ip": {
+                    "type": "string"
+                },
+                "create_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "audit.ListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/audit.ListData"
+                    }
+                },
+                "msg": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "config_info.BatchDeleteReq": {
+            "type": "object",
+            "required": [
+                "config_ids"
+            ],
+            "properties": {
+                "config_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "config_info.BetaContentData": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "beta_ips": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "beta_labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "content": {
+                    "type": "string"
+                },
+                "data_id": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "md5": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "update_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_info.BetaContentResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "$ref": "#/definitions/config_info.BetaContentData"
+                },
+                "msg": {
+                    "type": "string"
+                }
+            }
         },
         "config_info.CloneItems": {
             "type": "object",
             "required": [
-                "config_id",
-                "data_id",
-                "group_id"
+                "config_id",
+                "data_id",
+                "group_id"
+            ],
+            "properties": {
+                "config_id": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "data_id": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "group_id": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "config_info.CloneReq": {
+            "type": "object",
+            "properties": {
+                "force": {
+                    "description": "跳过内容格式及Schema校验，仅管理员有效",
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config_info.CloneItems"
+                    }
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_info.ContentByParamsData": {
+            "type": "object",
+            "properties": {
+                "config_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "create_time": {
+                    "type": "string"
+                },
+                "data_id": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_info.ContentByParamsResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "$ref": "#/definitions/config_info.ContentByParamsData"
+                },
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_info.ContentData": {
+            "type": "object",
+            "properties": {
+                "config_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "data_id": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "md5": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_info.ContentResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "$ref": "#/definitions/config_info.ContentData"
+                },
+                "msg": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "config_info.CreateReq": {
+            "type": "object",
+            "required": [
+                "data_id",
+                "group_id",
+                "tenant_id"
+            ],
+            "properties": {
+                "data_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "group_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "tenant_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "config_info.DiffData": {
+            "type": "object",
+            "properties": {
+                "data_id": {
+                    "type": "string"
+                },
+                "from_version": {
+                    "type": "integer"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "keys": {
+                    "$ref": "#/definitions/config_info.DiffKeyData"
+                },
+                "keys_err": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "to_version": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "unified": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_info.DiffKeyChange": {
+            "type": "object",
+            "properties": {
+                "from": {},
+                "key": {
+                    "type": "string"
+                },
+                "to": {}
+            }
+        },
+        "config_info.DiffKeyData": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config_info.DiffKeyChange"
+                    }
+                },
+                "changed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config_info.DiffKeyChange"
+                    }
+                },
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config_info.DiffKeyChange"
+                    }
+                }
+            }
+        },
+        "config_info.DiffResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "$ref": "#/definitions/config_info.DiffData"
+                },
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_info.ImportData": {
+            "type": "object",
+            "properties": {
+                "fail_data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config_info.ImportItem"
+                    }
+                },
+                "skip_count": {
+                    "type": "integer"
+                },
+                "succ_count": {
+                    "type": "integer"
+                },
+                "unrecognized_count": {
+                    "type": "integer"
+                },
+                "unrecognized_data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config_zip.Unrecognized"
+                    }
+                }
+            }
+        },
+        "config_info.ImportItem": {
+            "type": "object",
+            "properties": {
+                "data_id": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_info.ImportResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "$ref": "#/definitions/config_info.ImportData"
+                },
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_info.LanguageListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_info.ListData": {
+            "type": "object",
+            "properties": {
+                "config_id": {
+                    "type": "string"
+                },
+                "create_time": {
+                    "type": "string"
+                },
+                "data_id": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "is_beta": {
+                    "type": "boolean"
+                },
+                "md5": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_info.ListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config_info.ListData"
+                    }
+                },
+                "msg": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "config_info.ListVersionData": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "config_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "create_time": {
+                    "type": "string"
+                },
+                "data_id": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "md5": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_info.ListVersionResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config_info.ListVersionData"
+                    }
+                },
+                "msg": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "config_info.PublishBetaReq": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "beta_ips": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "beta_labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "content": {
+                    "type": "string"
+                },
+                "force": {
+                    "description": "跳过内容格式及Schema校验，仅管理员有效",
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "config_info.RollbackReq": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "force": {
+                    "description": "跳过内容格式及Schema校验，仅管理员有效",
+                    "type": "boolean"
+                },
+                "version": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "config_info.UpdateReq": {
+            "type": "object",
+            "properties": {
+                "base_version": {
+                    "description": "乐观锁：期望的当前最新版本号或内容md5，不一致时拒绝发布",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "cas_md5": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "data_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "force": {
+                    "description": "跳过内容格式及Schema校验，仅管理员有效",
+                    "type": "boolean"
+                },
+                "group_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "type": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "config_info.WatchEventData": {
+            "type": "object",
+            "properties": {
+                "data_id": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "is_beta": {
+                    "description": "是否命中灰度规则",
+                    "type": "boolean"
+                },
+                "md5": {
+                    "description": "请求方应获取的内容的md5，命中灰度规则时为灰度内容的md5，配置不存在时为空",
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "stale: 建立连接时已过期，publish / delete / beta: 对应的变更事件，ping: 心跳",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "正式版本号，配置不存在时为0",
+                    "type": "integer"
+                }
+            }
+        },
+        "config_schema.CreateReq": {
+            "type": "object",
+            "required": [
+                "content",
+                "data_id",
+                "group_id",
+                "tenant_id"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "data_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "group_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "tenant_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "config_schema.GetResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "$ref": "#/definitions/config_schema.SchemaData"
+                },
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_schema.ListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config_schema.SchemaData"
+                    }
+                },
+                "msg": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "config_schema.SchemaData": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "create_time": {
+                    "type": "string"
+                },
+                "data_id": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "update_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_schema.UpdateReq": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                }
+            }
+        },
+        "config_zip.Unrecognized": {
+            "type": "object",
+            "properties": {
+                "item_name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "permission.CreateReq": {
+            "type": "object",
+            "required": [
+                "action",
+                "resource",
+                "role"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "effect": {
+                    "description": "效果 allow(默认) 或 deny，拒绝规则优先于允许规则，例如允许 prod 的同时拒绝 prod:secrets:*",
+                    "type": "string",
+                    "enum": [
+                        "allow",
+                        "deny"
+                    ]
+                },
+                "resource": {
+                    "description": "资源 tenant:group:dataId，每一段都支持通配符 * 和 ?，只写 tenant 时表示整个命名空间，例如 prod:payment-*:*",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "role": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "permission.ExplainData": {
+            "type": "object",
+            "properties": {
+                "allow_rules": {
+                    "description": "与配置和操作匹配的允许规则",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/permission.ListData"
+                    }
+                },
+                "allowed": {
+                    "type": "boolean"
+                },
+                "deny_rules": {
+                    "description": "与配置和操作匹配的拒绝规则，只要有一条就不允许",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/permission.ListData"
+                    }
+                },
+                "is_admin": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "description": "判定原因",
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "permission.ExplainReq": {
+            "type": "object",
+            "required": [
+                "action",
+                "resource",
+                "username"
+            ],
+            "properties": {
+                "action": {
+                    "description": "r: 读取，w: 写入，rw: 同时读取和写入",
+                    "type": "string",
+                    "enum": [
+                        "r",
+                        "w",
+                        "rw"
+                    ]
+                },
+                "resource": {
+                    "description": "具体的配置 tenant:group:dataId，不能使用通配符",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "permission.ExplainResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "$ref": "#/definitions/permission.ExplainData"
+                },
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "permission.ListData": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "effect": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "permission.ListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/permission.ListData"
+                    }
+                },
+                "msg": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.Code": {
+            "type": "integer",
+            "enum": [
+                200,
+                401,
+                500,
+                501,
+                502,
+                503,
+                504,
+                505,
+                506,
+                507
+            ],
+            "x-enum-varnames": [
+                "Code_Success",
+                "Code_Unauthorized",
+                "Code_Err",
+                "Code_DBErr",
+                "Code_PasswordErr",
+                "Code_AlreadyExists",
+                "Code_CaptchaErr",
+                "Code_Conflict",
+                "Code_ValidateErr",
+                "Code_TotpRequired"
+            ]
+        },
+        "response.CommonResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "role.CreateReq": {
+            "type": "object",
+            "required": [
+                "role",
+                "username"
             ],
             "properties": {
-                "config_id": {
+                "role": {
                     "type": "string",
-                    "maxLength": 100,
+                    "maxLength": 255,
                     "minLength": 1
                 },
-                "data_id": {
+                "username": {
                     "type": "string",
-                    "maxLength": 100,
+                    "maxLength": 255,
                     "minLength": 1
+                }
+            }
+        },
+        "role.ListData": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
                 },
-                "group_id": {
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "role.ListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/role.ListData"
+                    }
+                },
+                "msg": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service_account.CreateKeyData": {
+            "type": "object",
+            "properties": {
+                "expire_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "API Key 明文，只在创建时返回一次",
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string"
+                }
+            }
+        },
+        "service_account.CreateKeyReq": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "expire_days": {
+                    "description": "有效天数，0 表示永不过期",
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 0
+                },
+                "name": {
                     "type": "string",
-                    "maxLength": 100,
+                    "maxLength": 255,
                     "minLength": 1
+                },
+                "scope": {
+                    "description": "权限范围：r 只读，rw 读写(默认)",
+                    "type": "string",
+                    "enum": [
+                        "r",
+                        "rw"
+                    ]
                 }
             }
         },
-        "config_info.CloneReq": {
+        "service_account.CreateKeyResp": {
             "type": "object",
             "properties": {
-                "items": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "$ref": "#/definitions/service_account.CreateKeyData"
+                },
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "service_account.CreateReq": {
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "username": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "service_account.KeyData": {
+            "type": "object",
+            "properties": {
+                "create_time": {
+                    "type": "string"
+                },
+                "expire_time": {
+                    "type": "string"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_ip": {
+                    "type": "string"
+                },
+                "last_used_time": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string"
+                }
+            }
+        },
+        "service_account.KeyListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/config_info.CloneItems"
+                        "$ref": "#/definitions/service_account.KeyData"
                     }
                 },
-                "tenant_id": {
+                "msg": {
                     "type": "string"
                 }
             }
         },
-        "config_info.ContentByParamsData": {
+        "service_account.ListData": {
             "type": "object",
             "properties": {
-                "config_id": {
+                "enable": {
+                    "type": "boolean"
+                },
+                "user_id": {
                     "type": "string"
                 },
-                "content": {
+                "username": {
                     "type": "string"
+                }
+            }
+        },
+        "service_account.ListResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
                 },
-                "create_time": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service_account.ListData"
+                    }
+                },
+                "msg": {
                     "type": "string"
                 },
-                "data_id": {
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "tenant.CreateReq": {
+            "type": "object",
+            "required": [
+                "tenant_desc",
+                "tenant_id",
+                "tenant_name"
+            ],
+            "properties": {
+                "tenant_desc": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "tenant_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "tenant_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "tenant.ListData": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string"
                 },
-                "group_id": {
+                "tenant_desc": {
                     "type": "string"
                 },
                 "tenant_id": {
                     "type": "string"
                 },
-                "type": {
+                "tenant_name": {
                     "type": "string"
                 }
             }
         },
-        "config_info.ContentByParamsResp": {
+        "tenant.ListResp": {
             "type": "object",
             "properties": {
                 "code": {
                     "$ref": "#/definitions/response.Code"
                 },
                 "data": {
-                    "$ref": "#/definitions/config_info.ContentByParamsData"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tenant.ListData"
+                    }
+                },
+                "msg": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "user.CaptchaData": {
+            "type": "object",
+            "properties": {
+                "base64_image": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "user.CaptchaResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "$ref": "#/definitions/user.CaptchaData"
+                },
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "user.ChangePasswdReq": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "user.CreateReq": {
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "is_admin": {
+                    "type": "boolean"
                 },
-                "msg": {
-                    "type": "string"
+                "username": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 }
             }
         },
-        "config_info.ContentData": {
+        "user.InfoData": {
             "type": "object",
             "properties": {
-                "config_id": {
-                    "type": "string"
-                },
-                "content": {
-                    "type": "string"
+                "enable": {
+                    "type": "boolean"
                 },
-                "data_id": {
-                    "type": "string"
+                "is_admin": {
+                    "type": "boolean"
                 },
-                "group_id": {
-                    "type": "string"
+                "is_service_account": {
+                    "type": "boolean"
                 },
-                "tenant_id": {
+                "user_id": {
                     "type": "string"
                 },
-                "type": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "config_info.ContentResp": {
+        "user.InfoResp": {
             "type": "object",
             "properties": {
                 "code": {
                     "$ref": "#/definitions/response.Code"
                 },
                 "data": {
-                    "$ref": "#/definitions/config_info.ContentData"
+                    "$ref": "#/definitions/user.InfoData"
                 },
                 "msg": {
                     "type": "string"
@@ -1838,121 +4922,99 @@ const docTemplate = `{
                 }
             }
         },
-        "config_info.CreateReq": {
-            "type": "object",
-            "required": [
-                "data_id",
-                "group_id",
-                "tenant_id"
-            ],
-            "properties": {
-                "data_id": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                },
-                "group_id": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                },
-                "tenant_id": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                }
-            }
-        },
-        "config_info.LanguageListResp": {
+        "user.LdapCheckData": {
             "type": "object",
             "properties": {
-                "code": {
-                    "$ref": "#/definitions/response.Code"
+                "groups": {
+                    "description": "用户所在的组",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "data": {
+                "roles": {
+                    "description": "按 role_mapping 同步的角色",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "msg": {
+                "user_dn": {
+                    "description": "用户DN，未指定用户名时为空",
                     "type": "string"
                 }
             }
         },
-        "config_info.ListData": {
+        "user.LdapCheckReq": {
             "type": "object",
             "properties": {
-                "config_id": {
-                    "type": "string"
-                },
-                "create_time": {
-                    "type": "string"
-                },
-                "data_id": {
-                    "type": "string"
-                },
-                "group_id": {
-                    "type": "string"
+                "password": {
+                    "description": "为空时不校验用户密码",
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "type": {
-                    "type": "string"
+                "username": {
+                    "description": "为空时只检查连接和服务账号绑定",
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "config_info.ListResp": {
+        "user.LdapCheckResp": {
             "type": "object",
             "properties": {
                 "code": {
                     "$ref": "#/definitions/response.Code"
                 },
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/config_info.ListData"
-                    }
+                    "$ref": "#/definitions/user.LdapCheckData"
                 },
                 "msg": {
                     "type": "string"
-                },
-                "total": {
-                    "type": "integer"
                 }
             }
         },
-        "config_info.ListVersionData": {
+        "user.ListData": {
             "type": "object",
             "properties": {
-                "author": {
-                    "type": "string"
+                "enable": {
+                    "type": "boolean"
                 },
-                "config_id": {
-                    "type": "string"
+                "failed_login_count": {
+                    "description": "连续登录失败次数",
+                    "type": "integer"
                 },
-                "content": {
-                    "type": "string"
+                "is_admin": {
+                    "type": "boolean"
                 },
-                "create_time": {
-                    "type": "string"
+                "is_service_account": {
+                    "type": "boolean"
                 },
-                "data_id": {
-                    "type": "string"
+                "locked": {
+                    "description": "是否因登录失败次数过多被锁定",
+                    "type": "boolean"
                 },
-                "group_id": {
+                "locked_until": {
+                    "description": "锁定截止时间，未锁定时为空",
                     "type": "string"
                 },
-                "tenant_id": {
+                "source": {
+                    "description": "用户来源: local / ldap / oidc",
                     "type": "string"
                 },
-                "type": {
+                "totp_enabled": {
+                    "description": "是否已启用两步验证",
+                    "type": "boolean"
+                },
+                "user_id": {
                     "type": "string"
                 },
-                "version": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "config_info.ListVersionResp": {
+        "user.ListResp": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1961,7 +5023,7 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/config_info.ListVersionData"
+                        "$ref": "#/definitions/user.ListData"
                     }
                 },
                 "msg": {
@@ -1972,128 +5034,123 @@ const docTemplate = `{
                 }
             }
         },
-        "config_info.UpdateReq": {
+        "user.LoginData": {
             "type": "object",
             "properties": {
-                "content": {
+                "expires_in": {
+                    "description": "token 有效期(秒)，过期前使用 refresh_token 换取新的 token",
+                    "type": "integer"
+                },
+                "mfa_token": {
+                    "description": "需要两步验证时返回，使用该令牌调用 /api/user/login/totp 完成登录",
                     "type": "string"
                 },
-                "data_id": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "recovery_codes": {
+                    "description": "登录时完成绑定后返回的恢复码，只返回一次",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "group_id": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "refresh_token": {
+                    "type": "string"
                 },
-                "type": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "token": {
+                    "type": "string"
+                },
+                "totp_enroll_required": {
+                    "description": "管理员要求启用两步验证但用户尚未绑定，需要先调用 /api/user/login/totp/enroll",
+                    "type": "boolean"
                 }
             }
         },
-        "permission.CreateReq": {
+        "user.LoginReq": {
             "type": "object",
             "required": [
-                "action",
-                "resource",
-                "role"
+                "captcha",
+                "captcha_id",
+                "password",
+                "username"
             ],
             "properties": {
-                "action": {
+                "captcha": {
                     "type": "string",
-                    "maxLength": 255,
+                    "maxLength": 10,
                     "minLength": 1
                 },
-                "resource": {
+                "captcha_id": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
                 },
-                "role": {
+                "password": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
-                }
-            }
-        },
-        "permission.ListData": {
-            "type": "object",
-            "properties": {
-                "action": {
-                    "type": "string"
                 },
-                "resource": {
-                    "type": "string"
+                "remember_me": {
+                    "type": "boolean"
                 },
-                "role": {
-                    "type": "string"
+                "username": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 }
             }
         },
-        "permission.ListResp": {
+        "user.LoginResp": {
             "type": "object",
             "properties": {
                 "code": {
                     "$ref": "#/definitions/response.Code"
                 },
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/permission.ListData"
-                    }
+                    "$ref": "#/definitions/user.LoginData"
                 },
                 "msg": {
                     "type": "string"
-                },
-                "total": {
-                    "type": "integer"
                 }
             }
         },
-        "response.Code": {
-            "type": "integer",
-            "enum": [
-                200,
-                401,
-                500,
-                501,
-                502,
-                503,
-                504
+        "user.LoginTotpEnrollReq": {
+            "type": "object",
+            "required": [
+                "mfa_token"
             ],
-            "x-enum-varnames": [
-                "Code_Success",
-                "Code_Unauthorized",
-                "Code_Err",
-                "Code_DBErr",
-                "Code_PasswordErr",
-                "Code_AlreadyExists",
-                "Code_CaptchaErr"
-            ]
+            "properties": {
+                "mfa_token": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
         },
-        "response.CommonResp": {
+        "user.LoginTotpReq": {
             "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
             "properties": {
                 "code": {
-                    "$ref": "#/definitions/response.Code"
+                    "description": "验证器应用生成的验证码或恢复码",
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 1
                 },
-                "msg": {
-                    "type": "string"
+                "mfa_token": {
+                    "type": "string",
+                    "minLength": 1
                 }
             }
         },
-        "role.CreateReq": {
+        "user.NacosLoginReq": {
             "type": "object",
             "required": [
-                "role",
+                "password",
                 "username"
             ],
             "properties": {
-                "role": {
+                "password": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
@@ -2105,80 +5162,77 @@ const docTemplate = `{
                 }
             }
         },
-        "role.ListData": {
+        "user.NacosLoginResp": {
             "type": "object",
             "properties": {
-                "role": {
-                    "type": "string"
-                },
-                "username": {
+                "accessToken": {
                     "type": "string"
                 }
             }
         },
-        "role.ListResp": {
+        "user.RefreshTokenReq": {
             "type": "object",
+            "required": [
+                "refresh_token"
+            ],
             "properties": {
-                "code": {
-                    "$ref": "#/definitions/response.Code"
-                },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/role.ListData"
-                    }
-                },
-                "msg": {
-                    "type": "string"
-                },
-                "total": {
-                    "type": "integer"
+                "refresh_token": {
+                    "type": "string",
+                    "minLength": 1
                 }
             }
         },
-        "tenant.CreateReq": {
+        "user.TotpCodeReq": {
             "type": "object",
             "required": [
-                "tenant_desc",
-                "tenant_id",
-                "tenant_name"
+                "code"
             ],
             "properties": {
-                "tenant_desc": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                },
-                "tenant_id": {
+                "code": {
                     "type": "string",
-                    "maxLength": 255,
+                    "maxLength": 20,
                     "minLength": 1
-                },
-                "tenant_name": {
+                }
+            }
+        },
+        "user.TotpDisableBody": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "停用自己的两步验证时需要提供验证码或恢复码",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                    "maxLength": 20
                 }
             }
         },
-        "tenant.ListData": {
+        "user.TotpEnrollData": {
             "type": "object",
             "properties": {
-                "id": {
+                "secret": {
+                    "description": "base32 编码的密钥，无法扫码时手动输入",
                     "type": "string"
                 },
-                "tenant_desc": {
+                "url": {
+                    "description": "验证器应用扫码使用的地址",
                     "type": "string"
+                }
+            }
+        },
+        "user.TotpEnrollResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
                 },
-                "tenant_id": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/user.TotpEnrollData"
                 },
-                "tenant_name": {
+                "msg": {
                     "type": "string"
                 }
             }
         },
-        "tenant.ListResp": {
+        "user.TotpRecoveryCodesResp": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2187,90 +5241,201 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/tenant.ListData"
+                        "type": "string"
                     }
                 },
                 "msg": {
                     "type": "string"
-                },
-                "total": {
-                    "type": "integer"
                 }
             }
         },
-        "user.CaptchaData": {
+        "user.TotpRequireReq": {
             "type": "object",
+            "required": [
+                "required"
+            ],
             "properties": {
-                "base64_image": {
-                    "type": "string"
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "user.TotpStatusData": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "当前用户是否已启用两步验证",
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "string"
+                "recovery_codes_left": {
+                    "description": "剩余可用的恢复码数量",
+                    "type": "integer"
+                },
+                "required": {
+                    "description": "管理员是否要求所有用户启用两步验证",
+                    "type": "boolean"
                 }
             }
         },
-        "user.CaptchaResp": {
+        "user.TotpStatusResp": {
             "type": "object",
             "properties": {
                 "code": {
                     "$ref": "#/definitions/response.Code"
                 },
                 "data": {
-                    "$ref": "#/definitions/user.CaptchaData"
+                    "$ref": "#/definitions/user.TotpStatusData"
                 },
                 "msg": {
                     "type": "string"
                 }
             }
         },
-        "user.ChangePasswdReq": {
+        "user.UpdateReq": {
             "type": "object",
-            "required": [
-                "password"
-            ],
             "properties": {
-                "password": {
+                "enable": {
+                    "type": "boolean"
+                },
+                "is_admin": {
+                    "description": "授予或取消管理员权限，仅管理员可修改",
+                    "type": "boolean"
+                },
+                "source": {
+                    "description": "修改用户来源，仅管理员可修改，用于把早期版本由 LDAP 登录创建的用户改为 LDAP 用户，或改回本地用户",
+                    "type": "string",
+                    "enum": [
+                        "local",
+                        "ldap"
+                    ]
+                },
+                "username": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
                 }
             }
         },
-        "user.CreateReq": {
+        "webhook.CreateReq": {
             "type": "object",
             "required": [
-                "username"
+                "name",
+                "tenant_id",
+                "url"
             ],
             "properties": {
-                "username": {
+                "data_id_filter": {
+                    "description": "配置ID过滤，支持通配符 * 和 ?，为空时不过滤",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "enabled": {
+                    "description": "是否启用，默认启用",
+                    "type": "boolean"
+                },
+                "events": {
+                    "description": "订阅的事件: publish / delete / beta，为空时订阅所有事件",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "group_filter": {
+                    "description": "分组过滤，支持通配符 * 和 ?，为空时不过滤",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "kind": {
+                    "description": "消息格式: generic / slack / dingtalk / feishu，默认为 generic",
+                    "type": "string",
+                    "enum": [
+                        "generic",
+                        "slack",
+                        "dingtalk",
+                        "feishu"
+                    ]
+                },
+                "name": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
+                },
+                "secret": {
+                    "description": "签名密钥，generic 使用 HMAC-SHA256 签名请求体并放在 X-Confkeeper-Signature 中",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "tenant_id": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 1
+                },
+                "url": {
+                    "description": "回调地址，不能是内网地址，需要回调内网服务时由管理员配置 webhook.allowed_networks",
+                    "type": "string",
+                    "maxLength": 1024,
+                    "minLength": 1
                 }
             }
         },
-        "user.InfoData": {
+        "webhook.DeliveryData": {
             "type": "object",
             "properties": {
-                "enable": {
+                "attempts": {
+                    "description": "已尝试次数，包括第一次投递",
+                    "type": "integer"
+                },
+                "create_time": {
+                    "type": "string"
+                },
+                "data_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "description": "事件类型: publish / delete / beta / test",
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "response": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "description": "最后一次请求的响应状态码，请求失败时为0",
+                    "type": "integer"
+                },
+                "success": {
                     "type": "boolean"
                 },
-                "user_id": {
+                "tenant_id": {
                     "type": "string"
                 },
-                "username": {
+                "update_time": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "user.InfoResp": {
+        "webhook.DeliveryListResp": {
             "type": "object",
             "properties": {
                 "code": {
                     "$ref": "#/definitions/response.Code"
                 },
                 "data": {
-                    "$ref": "#/definitions/user.InfoData"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webhook.DeliveryData"
+                    }
                 },
                 "msg": {
                     "type": "string"
@@ -2280,21 +5445,7 @@ const docTemplate = `{
                 }
             }
         },
-        "user.ListData": {
-            "type": "object",
-            "properties": {
-                "enable": {
-                    "type": "boolean"
-                },
-                "user_id": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
-        },
-        "user.ListResp": {
+        "webhook.ListResp": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2303,7 +5454,7 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/user.ListData"
+                        "$ref": "#/definitions/webhook.WebhookData"
                     }
                 },
                 "msg": {
@@ -2314,99 +5465,113 @@ const docTemplate = `{
                 }
             }
         },
-        "user.LoginData": {
+        "webhook.TestResp": {
             "type": "object",
             "properties": {
-                "token": {
+                "code": {
+                    "$ref": "#/definitions/response.Code"
+                },
+                "data": {
+                    "$ref": "#/definitions/webhook.DeliveryData"
+                },
+                "msg": {
                     "type": "string"
                 }
             }
         },
-        "user.LoginReq": {
+        "webhook.UpdateReq": {
             "type": "object",
-            "required": [
-                "captcha",
-                "captcha_id",
-                "password",
-                "username"
-            ],
             "properties": {
-                "captcha": {
+                "data_id_filter": {
                     "type": "string",
-                    "maxLength": 10,
-                    "minLength": 1
+                    "maxLength": 255
                 },
-                "captcha_id": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "group_filter": {
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                    "maxLength": 255
                 },
-                "password": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "generic",
+                        "slack",
+                        "dingtalk",
+                        "feishu"
+                    ]
+                },
+                "name": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
                 },
-                "remember_me": {
-                    "type": "boolean"
+                "secret": {
+                    "description": "为空字符串时清除密钥，不传时保持不变",
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "username": {
+                "url": {
                     "type": "string",
-                    "maxLength": 255,
+                    "maxLength": 1024,
                     "minLength": 1
                 }
             }
         },
-        "user.LoginResp": {
+        "webhook.WebhookData": {
             "type": "object",
             "properties": {
-                "code": {
-                    "$ref": "#/definitions/response.Code"
+                "author": {
+                    "type": "string"
                 },
-                "data": {
-                    "$ref": "#/definitions/user.LoginData"
+                "create_time": {
+                    "type": "string"
                 },
-                "msg": {
+                "data_id_filter": {
                     "type": "string"
-                }
-            }
-        },
-        "user.NacosLoginReq": {
-            "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
-            "properties": {
-                "password": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
                 },
-                "username": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                }
-            }
-        },
-        "user.NacosLoginResp": {
-            "type": "object",
-            "properties": {
-                "accessToken": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "group_filter": {
                     "type": "string"
-                }
-            }
-        },
-        "user.UpdateReq": {
-            "type": "object",
-            "properties": {
-                "enable": {
+                },
+                "has_secret": {
+                    "description": "是否设置了签名密钥，密钥本身不返回",
                     "type": "boolean"
                 },
-                "username": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "消息格式: generic / slack / dingtalk / feishu",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "update_time": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "没有管理权限时只返回协议和主机，聊天机器人的地址中包含令牌",
+                    "type": "string"
                 }
             }
         }
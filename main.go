@@ -8,6 +8,8 @@ import (
 	"confkeeper/utils/config"
 	"confkeeper/utils/cron"
 	"confkeeper/utils/logger"
+	"confkeeper/utils/session"
+	"confkeeper/utils/webhook_client"
 	"embed"
 	_ "embed"
 	"encoding/base64"
@@ -50,9 +52,17 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 	dal.Init()
+	if err := session.Init(dal.SessionStore); err != nil {
+		panic(err)
+	}
 	captcha.Init()
+	webhook_client.Start()
 	gin.ForceConsoleColor()
 	r := gin.Default()
+	// 默认不信任任何代理，避免客户端伪造 X-Forwarded-For 绕过按IP的限制
+	if err := r.SetTrustedProxies(config.Cfg.Server.TrustedProxies); err != nil {
+		panic(err)
+	}
 	r.Use(mw.StaticFileMiddleware(staticFS))
 
 	// 注册路由
@@ -76,6 +86,9 @@ func main() {
 		}))
 	}
 
+	go cron.AuditCleanupTask()
+	go cron.WebhookCleanupTask()
+
 	if config.Cfg.Server.IsDemo {
 		slog.Info("演示模式已启用")
 		go cron.CleanupTask()
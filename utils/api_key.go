@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// apiKeyPrefix API Key 的固定前缀，用于和 JWT 区分
+const apiKeyPrefix = "ck_"
+
+// GenerateApiKey 生成随机 API Key，返回密钥明文和用于展示的前缀
+func GenerateApiKey() (key string, prefix string, err error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	key = apiKeyPrefix + hex.EncodeToString(buf)
+	return key, key[:len(apiKeyPrefix)+8], nil
+}
+
+// IsApiKey 判断 token 是否为 API Key
+func IsApiKey(token string) bool {
+	return strings.HasPrefix(token, apiKeyPrefix)
+}
+
+// HashApiKey 计算 API Key 的存储摘要，API Key 为高熵随机串，使用 sha256 即可
+func HashApiKey(key string) string {
+	return SHA256(key)
+}
@@ -20,6 +20,8 @@ type ServerConfig struct {
 	DeleteDataCron    string `mapstructure:"delete_data_cron"`
 	Zone              string `mapstructure:"zone"`
 	CaptchaExpireTime int    `mapstructure:"captcha_expire_time"`
+	// 可信的反向代理，只有来自这些地址的请求才从 X-Forwarded-For 等请求头读取客户端IP
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 type DbConfig struct {
@@ -35,7 +37,9 @@ type JwtConfig struct {
 	EnableMemory     bool   `mapstructure:"enable_memory"`
 	Secret           string `mapstructure:"secret"`
 	ExpireTime       int    `mapstructure:"expire_time"`
+	AccessExpireTime int    `mapstructure:"access_expire_time"`
 	MaxLoginSessions int    `mapstructure:"max_login_sessions"`
+	SessionStore     string `mapstructure:"session_store"`
 }
 
 type AdminConfig struct {
@@ -54,12 +58,72 @@ type ConfkeeperConfig struct {
 }
 
 type LdapConfig struct {
-	Enabled  bool   `mapstructure:"enabled"`
+	Enabled            bool          `mapstructure:"enabled"`
+	Addr               string        `mapstructure:"addr"`
+	BaseDN             string        `mapstructure:"base_dn"`
+	BindDN             string        `mapstructure:"bind_dn"`
+	BindPass           string        `mapstructure:"bind_pass"`
+	TLS                bool          `mapstructure:"tls"`
+	StartTLS           bool          `mapstructure:"start_tls"`
+	CACert             string        `mapstructure:"ca_cert"`
+	ServerName         string        `mapstructure:"server_name"`
+	InsecureSkipVerify bool          `mapstructure:"insecure_skip_verify"`
+	UserFilter         string        `mapstructure:"user_filter"`
+	GroupType          string        `mapstructure:"group_type"`
+	GroupBaseDN        string        `mapstructure:"group_base_dn"`
+	GroupFilter        string        `mapstructure:"group_filter"`
+	GroupNameAttr      string        `mapstructure:"group_name_attr"`
+	RoleMapping        []RoleMapping `mapstructure:"role_mapping"`
+}
+
+type OidcConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Issuer        string        `mapstructure:"issuer"`
+	ClientID      string        `mapstructure:"client_id"`
+	ClientSecret  string        `mapstructure:"client_secret"`
+	RedirectURL   string        `mapstructure:"redirect_url"`
+	Scopes        []string      `mapstructure:"scopes"`
+	UsernameClaim string        `mapstructure:"username_claim"`
+	GroupsClaim   string        `mapstructure:"groups_claim"`
+	RoleMapping   []RoleMapping `mapstructure:"role_mapping"`
+	SuccessURL    string        `mapstructure:"success_url"`
+}
+
+// RoleMapping 外部身份源的用户组对应的角色，viper 会把 map 的 key 转为小写，所以使用列表
+type RoleMapping struct {
+	Group string `mapstructure:"group"`
+	Role  string `mapstructure:"role"`
+}
+
+type RedisConfig struct {
 	Addr     string `mapstructure:"addr"`
-	BaseDN   string `mapstructure:"base_dn"`
-	BindDN   string `mapstructure:"bind_dn"`
-	BindPass string `mapstructure:"bind_pass"`
-	TLS      bool   `mapstructure:"tls"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+type PasswordConfig struct {
+	Algorithm  string `mapstructure:"algorithm"`
+	BcryptCost int    `mapstructure:"bcrypt_cost"`
+}
+
+type LoginLimitConfig struct {
+	MaxAttempts   int `mapstructure:"max_attempts"`
+	IpMaxAttempts int `mapstructure:"ip_max_attempts"`
+	LockTime      int `mapstructure:"lock_time"`
+	MaxLockTime   int `mapstructure:"max_lock_time"`
+}
+
+type AuditConfig struct {
+	RetentionDays int `mapstructure:"retention_days"`
+}
+
+type WebhookConfig struct {
+	Timeout       int `mapstructure:"timeout"`
+	MaxRetries    int `mapstructure:"max_retries"`
+	RetryInterval int `mapstructure:"retry_interval"`
+	RetentionDays int `mapstructure:"retention_days"`
+	// 允许回调的内网地址段
+	AllowedNetworks []string `mapstructure:"allowed_networks"`
 }
 
 type AppConfig struct {
@@ -70,6 +134,12 @@ type AppConfig struct {
 	Captcha    CaptchaConfig    `mapstructure:"captcha"`
 	Confkeeper ConfkeeperConfig `mapstructure:"confkeeper"`
 	Ldap       LdapConfig       `mapstructure:"ldap"`
+	Oidc       OidcConfig       `mapstructure:"oidc"`
+	Password   PasswordConfig   `mapstructure:"password"`
+	LoginLimit LoginLimitConfig `mapstructure:"login_limit"`
+	Redis      RedisConfig      `mapstructure:"redis"`
+	Audit      AuditConfig      `mapstructure:"audit"`
+	Webhook    WebhookConfig    `mapstructure:"webhook"`
 }
 
 var Cfg AppConfig
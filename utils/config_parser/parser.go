@@ -0,0 +1,80 @@
+package config_parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// structuredTypes 支持解析为键值结构的配置类型
+var structuredTypes = []string{"json", "yaml", "toml", "properties"}
+
+// IsStructured 判断配置类型是否可以解析为键值结构
+func IsStructured(configType string) bool {
+	return slices.Contains(structuredTypes, configType)
+}
+
+// Parse 将配置内容解析为通用结构(map[string]any / []any / 标量)
+func Parse(configType string, content string) (any, error) {
+	var data any
+	switch configType {
+	case "json":
+		decoder := json.NewDecoder(strings.NewReader(content))
+		decoder.UseNumber()
+		if err := decoder.Decode(&data); err != nil {
+			return nil, err
+		}
+	case "yaml":
+		if err := yaml.Unmarshal([]byte(content), &data); err != nil {
+			return nil, err
+		}
+	case "toml":
+		var table map[string]any
+		if err := toml.Unmarshal([]byte(content), &table); err != nil {
+			return nil, err
+		}
+		data = table
+	case "properties":
+		props, err := ParseProperties(content)
+		if err != nil {
+			return nil, err
+		}
+		table := make(map[string]any, len(props))
+		for k, v := range props {
+			table[k] = v
+		}
+		data = table
+	default:
+		return nil, fmt.Errorf("配置类型 %s 不支持解析", configType)
+	}
+
+	return normalize(data), nil
+}
+
+// normalize 统一 map 的键为字符串，方便后续比较和序列化
+func normalize(data any) any {
+	switch v := data.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalize(item)
+		}
+		return v
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = normalize(item)
+		}
+		return m
+	case []any:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
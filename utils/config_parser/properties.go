@@ -0,0 +1,126 @@
+package config_parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PropertiesError properties 解析错误
+type PropertiesError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *PropertiesError) Error() string {
+	return fmt.Sprintf("properties: line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// ParseProperties 解析 java properties 格式内容
+// 支持 # 和 ! 注释、= : 空白分隔符、反斜杠续行以及 \uXXXX 转义
+func ParseProperties(content string) (map[string]string, error) {
+	props := make(map[string]string)
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		startLine := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+		indent := len(lines[i]) - len(line)
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		// 处理续行：行尾奇数个反斜杠表示续行
+		for endsWithContinuation(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		if endsWithContinuation(line) {
+			line = line[:len(line)-1]
+		}
+
+		rawKey, rawValue, valueStart := splitProperty(line)
+		key, err := unescapeProperty(rawKey, startLine, indent+1)
+		if err != nil {
+			return nil, err
+		}
+		value, err := unescapeProperty(rawValue, startLine, indent+valueStart+1)
+		if err != nil {
+			return nil, err
+		}
+		props[key] = value
+	}
+
+	return props, nil
+}
+
+// endsWithContinuation 判断行尾是否为续行符
+func endsWithContinuation(line string) bool {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+	return count%2 == 1
+}
+
+// splitProperty 拆分键和值，返回值在行中的起始位置
+func splitProperty(line string) (string, string, int) {
+	keyEnd := len(line)
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if line[i] == '=' || line[i] == ':' || line[i] == ' ' || line[i] == '\t' || line[i] == '\f' {
+			keyEnd = i
+			break
+		}
+	}
+
+	key := line[:keyEnd]
+	rest := strings.TrimLeft(line[keyEnd:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	return key, rest, len(line) - len(rest)
+}
+
+// unescapeProperty 处理转义字符，column 为 s 在行中的起始列
+func unescapeProperty(s string, line int, column int) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", &PropertiesError{Line: line, Column: column + i - 1, Msg: "不完整的 \\u 转义"}
+			}
+			r, err := strconv.ParseUint(s[i+1:i+5], 16, 32)
+			if err != nil {
+				return "", &PropertiesError{Line: line, Column: column + i - 1, Msg: "非法的 \\u 转义: \\u" + s[i+1:i+5]}
+			}
+			sb.WriteRune(rune(r))
+			i += 4
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String(), nil
+}
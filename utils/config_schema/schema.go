@@ -0,0 +1,137 @@
+package config_schema
+
+import (
+	"bytes"
+	"confkeeper/utils/config_parser"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// schemaResource 编译时使用的虚拟资源地址
+const schemaResource = "confkeeper://config-schema.json"
+
+// supportedTypes 支持按 JSON Schema 校验的配置类型
+var supportedTypes = []string{"json", "yaml", "toml"}
+
+var printer = message.NewPrinter(language.English)
+
+// Violation 单条 schema 校验失败信息
+type Violation struct {
+	// JSON Pointer 格式的实例路径，根节点为空字符串
+	Path string `json:"path"`
+	Msg  string `json:"msg"`
+}
+
+// SchemaError 配置内容不符合 schema
+type SchemaError struct {
+	Violations []*Violation
+}
+
+func (e *SchemaError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		path := v.Path
+		if path == "" {
+			path = "(root)"
+		}
+		msgs = append(msgs, path+": "+v.Msg)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// IsSupported 判断配置类型是否支持 schema 校验
+func IsSupported(configType string) bool {
+	return slices.Contains(supportedTypes, configType)
+}
+
+// Compile 编译 JSON Schema，不允许通过 $ref 加载外部资源
+func Compile(schema string) (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("schema 不是合法的 json: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(jsonschema.SchemeURLLoader{})
+	if err := compiler.AddResource(schemaResource, doc); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(schemaResource)
+}
+
+// Validate 将配置内容统一转换为 json 后按 schema 校验
+// 不支持的配置类型直接通过，内容不符合 schema 时返回 *SchemaError
+func Validate(schema string, configType string, content string) error {
+	if !IsSupported(configType) {
+		return nil
+	}
+
+	compiled, err := Compile(schema)
+	if err != nil {
+		return err
+	}
+
+	instance, err := toJSONInstance(configType, content)
+	if err != nil {
+		return err
+	}
+
+	err = compiled.Validate(instance)
+	var validationErr *jsonschema.ValidationError
+	if errors.As(err, &validationErr) {
+		schemaErr := new(SchemaError)
+		collectViolations(validationErr, schemaErr)
+		return schemaErr
+	}
+	return err
+}
+
+// toJSONInstance 解析配置内容并经 json 序列化统一类型(如 toml 时间、yaml 整数)
+func toJSONInstance(configType string, content string) (any, error) {
+	// 空内容视为 null
+	if strings.TrimSpace(content) == "" {
+		return nil, nil
+	}
+
+	data, err := config_parser.Parse(configType, content)
+	if err != nil {
+		return nil, fmt.Errorf("解析配置内容失败: %w", err)
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("配置内容转换为 json 失败: %w", err)
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+}
+
+// collectViolations 收集叶子节点错误，分组类错误只作为容器
+func collectViolations(err *jsonschema.ValidationError, schemaErr *SchemaError) {
+	if len(err.Causes) == 0 {
+		schemaErr.Violations = append(schemaErr.Violations, &Violation{
+			Path: jsonPointer(err.InstanceLocation),
+			Msg:  err.ErrorKind.LocalizedString(printer),
+		})
+		return
+	}
+	for _, cause := range err.Causes {
+		collectViolations(cause, schemaErr)
+	}
+}
+
+// jsonPointer 按 RFC 6901 拼接路径
+func jsonPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteByte('/')
+		token = strings.ReplaceAll(token, "~", "~0")
+		sb.WriteString(strings.ReplaceAll(token, "/", "~1"))
+	}
+	return sb.String()
+}
@@ -0,0 +1,266 @@
+package config_validator
+
+import (
+	"bytes"
+	"confkeeper/utils/config_parser"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"golang.org/x/net/html"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	Register("json", validateJSON)
+	Register("yaml", validateYAML)
+	Register("xml", validateXML)
+	Register("toml", validateTOML)
+	Register("ini", validateINI)
+	Register("properties", validateProperties)
+	Register("html", validateHTML)
+}
+
+// validateJSON 校验 json 内容
+func validateJSON(content string) error {
+	var data any
+	err := json.Unmarshal([]byte(content), &data)
+	if err == nil {
+		return nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		// Offset 为读取出错字节之后的偏移量
+		line, column := positionOf(content, int(syntaxErr.Offset)-1)
+		return &ValidationError{Line: line, Column: column, Msg: syntaxErr.Error()}
+	}
+	return &ValidationError{Msg: err.Error()}
+}
+
+// yamlErrorPattern yaml.v3 的错误信息中只带行号
+var yamlErrorPattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// validateYAML 校验 yaml 内容，与 config_parser 一样使用 yaml.v3 解析
+func validateYAML(content string) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &node); err != nil {
+		return yamlValidationError(err)
+	}
+	if node.Kind == 0 {
+		// 空内容
+		return nil
+	}
+	if err := checkYAMLDuplicateKeys(&node); err != nil {
+		return err
+	}
+
+	var data any
+	if err := node.Decode(&data); err != nil {
+		return yamlValidationError(err)
+	}
+	return nil
+}
+
+// checkYAMLDuplicateKeys 检查同一层级的重复键，使用节点的位置定位到重复的键
+func checkYAMLDuplicateKeys(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		seen := make(map[string]bool, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if key.Kind != yaml.ScalarNode || key.Tag == "!!merge" {
+				continue
+			}
+			if seen[key.Value] {
+				return &ValidationError{Line: key.Line, Column: key.Column, Msg: fmt.Sprintf("键 %s 重复", key.Value)}
+			}
+			seen[key.Value] = true
+		}
+	}
+	for _, child := range node.Content {
+		if err := checkYAMLDuplicateKeys(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func yamlValidationError(err error) error {
+	msg := err.Error()
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		msg = typeErr.Errors[0]
+	}
+	if match := yamlErrorPattern.FindStringSubmatch(msg); match != nil {
+		line, _ := strconv.Atoi(match[1])
+		return &ValidationError{Line: line, Msg: match[2]}
+	}
+	return &ValidationError{Msg: msg}
+}
+
+// validateXML 校验 xml 内容，要求有且仅有一个根元素
+func validateXML(content string) error {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	// 内容已是 utf-8 字符串，忽略声明中的编码
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	depth, roots := 0, 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		line, column := decoder.InputPos()
+		if err != nil {
+			var syntaxErr *xml.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				return &ValidationError{Line: line, Column: column, Msg: syntaxErr.Msg}
+			}
+			return &ValidationError{Line: line, Column: column, Msg: err.Error()}
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				roots++
+				if roots > 1 {
+					return &ValidationError{Line: line, Column: column, Msg: "存在多个根元素"}
+				}
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && len(bytes.TrimSpace(t)) > 0 {
+				return &ValidationError{Line: line, Column: column, Msg: "根元素之外存在文本内容"}
+			}
+		}
+	}
+
+	if roots == 0 {
+		return &ValidationError{Msg: "缺少根元素"}
+	}
+	return nil
+}
+
+// validateTOML 校验 toml 内容
+func validateTOML(content string) error {
+	var data map[string]any
+	err := toml.Unmarshal([]byte(content), &data)
+	if err == nil {
+		return nil
+	}
+
+	var decodeErr *toml.DecodeError
+	if errors.As(err, &decodeErr) {
+		line, column := decodeErr.Position()
+		return &ValidationError{Line: line, Column: column, Msg: decodeErr.Error()}
+	}
+	return &ValidationError{Msg: err.Error()}
+}
+
+// validateINI 校验 ini 内容
+// 支持 [section]、key=value / key:value 以及 ; # 注释
+func validateINI(content string) error {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, raw := range lines {
+		line := strings.TrimSpace(raw)
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		indent := len([]rune(raw)) - len([]rune(strings.TrimLeft(raw, " \t")))
+
+		if line[0] == '[' {
+			end := strings.IndexByte(line, ']')
+			if end < 0 {
+				return &ValidationError{Line: i + 1, Column: indent + len([]rune(line)) + 1, Msg: "节名缺少 ]"}
+			}
+			if strings.TrimSpace(line[1:end]) == "" {
+				return &ValidationError{Line: i + 1, Column: indent + 1, Msg: "节名为空"}
+			}
+			if rest := strings.TrimSpace(line[end+1:]); rest != "" && rest[0] != ';' && rest[0] != '#' {
+				return &ValidationError{Line: i + 1, Column: indent + len([]rune(line[:end+1])) + 1, Msg: "节名之后存在多余内容"}
+			}
+			continue
+		}
+
+		sep := strings.IndexAny(line, "=:")
+		if sep < 0 {
+			return &ValidationError{Line: i + 1, Column: indent + 1, Msg: "缺少 = 或 : 分隔符"}
+		}
+		if strings.TrimSpace(line[:sep]) == "" {
+			return &ValidationError{Line: i + 1, Column: indent + 1, Msg: "键名为空"}
+		}
+	}
+	return nil
+}
+
+// validateProperties 校验 properties 内容
+func validateProperties(content string) error {
+	_, err := config_parser.ParseProperties(content)
+	if err == nil {
+		return nil
+	}
+
+	var propErr *config_parser.PropertiesError
+	if errors.As(err, &propErr) {
+		return &ValidationError{Line: propErr.Line, Column: propErr.Column, Msg: propErr.Msg}
+	}
+	return &ValidationError{Msg: err.Error()}
+}
+
+// htmlVoidElements 没有结束标签的 html 元素
+var htmlVoidElements = []string{
+	"area", "base", "br", "col", "embed", "hr", "img", "input",
+	"link", "meta", "param", "source", "track", "wbr",
+}
+
+// validateHTML 校验 html 内容
+// 按浏览器习惯允许省略结束标签，但结束标签必须能匹配到已打开的元素
+func validateHTML(content string) error {
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+
+	var stack []string
+	offset := 0
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if err := tokenizer.Err(); err != io.EOF {
+				line, column := positionOf(content, offset)
+				return &ValidationError{Line: line, Column: column, Msg: err.Error()}
+			}
+			return nil
+		}
+		start := offset
+		offset += len(tokenizer.Raw())
+
+		switch tokenType {
+		case html.StartTagToken:
+			name, _ := tokenizer.TagName()
+			if !slices.Contains(htmlVoidElements, string(name)) {
+				stack = append(stack, string(name))
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			// 从栈顶向下查找匹配的开始标签，中间未关闭的元素视为隐式关闭
+			index := len(stack) - 1
+			for index >= 0 && stack[index] != string(name) {
+				index--
+			}
+			if index < 0 {
+				line, column := positionOf(content, start)
+				return &ValidationError{Line: line, Column: column, Msg: fmt.Sprintf("结束标签 </%s> 没有对应的开始标签", name)}
+			}
+			stack = stack[:index]
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package config_validator
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Validator 配置内容校验函数，校验失败时应返回 *ValidationError 以携带位置信息
+type Validator func(content string) error
+
+// ValidationError 配置内容校验错误
+type ValidationError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Line <= 0 {
+		return e.Msg
+	}
+	if e.Column > 0 {
+		return fmt.Sprintf("第%d行第%d列: %s", e.Line, e.Column, e.Msg)
+	}
+	return fmt.Sprintf("第%d行: %s", e.Line, e.Msg)
+}
+
+var (
+	validators = make(map[string]Validator)
+	lock       sync.RWMutex
+)
+
+// Register 注册指定配置类型的校验器，重复注册时覆盖
+func Register(configType string, validator Validator) {
+	lock.Lock()
+	defer lock.Unlock()
+	validators[configType] = validator
+}
+
+// Validate 按配置类型校验内容
+// 未注册校验器的类型(如 text)以及空内容直接通过
+func Validate(configType string, content string) error {
+	lock.RLock()
+	validator, ok := validators[configType]
+	lock.RUnlock()
+
+	if !ok || strings.TrimSpace(content) == "" {
+		return nil
+	}
+	return validator(content)
+}
+
+// positionOf 将字节偏移量转换为行列号(从1开始，列按字符计算)
+func positionOf(content string, offset int) (line int, column int) {
+	offset = min(max(offset, 0), len(content))
+	prefix := content[:offset]
+	line = strings.Count(prefix, "\n") + 1
+	column = utf8.RuneCountInString(prefix[strings.LastIndex(prefix, "\n")+1:]) + 1
+	return line, column
+}
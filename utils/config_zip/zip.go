@@ -0,0 +1,217 @@
+package config_zip
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// nacos v2 导出格式的元数据文件
+	metadataFile = ".metadata.yml"
+	// nacos v1 导出格式的元数据文件，只记录 appName
+	legacyMetaFile = ".meta.yml"
+
+	// 单个配置文件解压后的最大大小
+	maxItemSize = 10 << 20
+	// 压缩包解压后的最大总大小
+	maxTotalSize = 100 << 20
+)
+
+// Item 压缩包中的一个配置
+type Item struct {
+	DataId  string
+	Group   string
+	Type    string
+	Content string
+}
+
+// Name 配置在压缩包中的文件名
+func (item *Item) Name() string {
+	return item.Group + "/" + item.DataId
+}
+
+// Unrecognized 无法识别的压缩包条目
+type Unrecognized struct {
+	ItemName string `json:"item_name"`
+	Reason   string `json:"reason"`
+}
+
+// metadataItem nacos v2 元数据条目
+type metadataItem struct {
+	Group   string `yaml:"group"`
+	DataId  string `yaml:"dataId"`
+	Type    string `yaml:"type"`
+	AppName string `yaml:"appName"`
+	Desc    string `yaml:"desc"`
+}
+
+type metadata struct {
+	Metadata []*metadataItem `yaml:"metadata"`
+}
+
+// Write 按 nacos v2 导出格式写入压缩包：group/dataId 配置文件 + .metadata.yml
+func Write(w io.Writer, items []*Item) error {
+	zw := zip.NewWriter(w)
+	now := time.Now()
+
+	meta := &metadata{Metadata: make([]*metadataItem, 0, len(items))}
+	for _, item := range items {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: item.Name(), Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, item.Content); err != nil {
+			return err
+		}
+		meta.Metadata = append(meta.Metadata, &metadataItem{
+			Group:  item.Group,
+			DataId: item.DataId,
+			Type:   item.Type,
+		})
+	}
+
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: metadataFile, Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(f)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(meta); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// Read 解析 nacos v1/v2 导出格式的压缩包
+// 存在 .metadata.yml 时以其声明的配置为准，否则按 v1 格式读取全部 group/dataId 文件并根据后缀推断类型
+func Read(r *zip.Reader) ([]*Item, []*Unrecognized, error) {
+	files := make(map[string]string)
+	var names []string
+	var meta *metadata
+	var unrecognized []*Unrecognized
+	total := 0
+
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		content, err := readFile(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		total += len(content)
+		if total > maxTotalSize {
+			return nil, nil, fmt.Errorf("压缩包解压后超过 %dMB", maxTotalSize>>20)
+		}
+
+		switch f.Name {
+		case metadataFile:
+			meta = new(metadata)
+			if err := yaml.Unmarshal([]byte(content), meta); err != nil {
+				return nil, nil, fmt.Errorf("解析 %s 失败: %w", metadataFile, err)
+			}
+		case legacyMetaFile:
+			// v1 元数据只包含 appName，忽略
+		default:
+			if _, _, ok := splitName(f.Name); !ok {
+				unrecognized = append(unrecognized, &Unrecognized{ItemName: f.Name, Reason: "文件名不是 group/dataId 格式"})
+				continue
+			}
+			// 同名文件只保留第一个，避免同一配置导入两次
+			if _, ok := files[f.Name]; ok {
+				unrecognized = append(unrecognized, &Unrecognized{ItemName: f.Name, Reason: "文件重复"})
+				continue
+			}
+			files[f.Name] = content
+			names = append(names, f.Name)
+		}
+	}
+
+	var items []*Item
+	if meta != nil {
+		declared := make(map[string]bool)
+		for _, m := range meta.Metadata {
+			if m == nil || m.Group == "" || m.DataId == "" {
+				unrecognized = append(unrecognized, &Unrecognized{ItemName: metadataFile, Reason: "元数据缺少 group 或 dataId"})
+				continue
+			}
+			item := &Item{DataId: m.DataId, Group: m.Group, Type: m.Type}
+			content, ok := files[item.Name()]
+			if !ok {
+				unrecognized = append(unrecognized, &Unrecognized{ItemName: item.Name(), Reason: "缺少配置文件"})
+				continue
+			}
+			if declared[item.Name()] {
+				unrecognized = append(unrecognized, &Unrecognized{ItemName: item.Name(), Reason: "元数据重复声明"})
+				continue
+			}
+			declared[item.Name()] = true
+			item.Content = content
+			items = append(items, item)
+		}
+		for _, name := range names {
+			if !declared[name] {
+				unrecognized = append(unrecognized, &Unrecognized{ItemName: name, Reason: "未在元数据中声明"})
+			}
+		}
+		return items, unrecognized, nil
+	}
+
+	for _, name := range names {
+		group, dataId, _ := splitName(name)
+		items = append(items, &Item{DataId: dataId, Group: group, Type: typeOf(dataId), Content: files[name]})
+	}
+	return items, unrecognized, nil
+}
+
+// readFile 读取压缩包中的单个文件，限制解压后的大小
+func readFile(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxItemSize+1))
+	if err != nil {
+		return "", fmt.Errorf("读取 %s 失败: %w", f.Name, err)
+	}
+	if len(data) > maxItemSize {
+		return "", fmt.Errorf("%s 超过 %dMB", f.Name, maxItemSize>>20)
+	}
+	return string(data), nil
+}
+
+// splitName 将 group/dataId 拆分为 group 和 dataId
+func splitName(name string) (group string, dataId string, ok bool) {
+	group, dataId, ok = strings.Cut(name, "/")
+	if !ok || group == "" || dataId == "" || strings.Contains(dataId, "/") {
+		return "", "", false
+	}
+	return group, dataId, true
+}
+
+// typeOf 根据 dataId 后缀推断配置类型
+func typeOf(dataId string) string {
+	ext := strings.TrimPrefix(path.Ext(dataId), ".")
+	switch strings.ToLower(ext) {
+	case "json", "xml", "yaml", "html", "properties", "toml", "ini":
+		return strings.ToLower(ext)
+	case "yml":
+		return "yaml"
+	case "htm":
+		return "html"
+	default:
+		return "text"
+	}
+}
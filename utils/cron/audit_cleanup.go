@@ -0,0 +1,43 @@
+package cron
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/utils/config"
+	"time"
+
+	"github.com/gookit/slog"
+	"github.com/robfig/cron/v3"
+)
+
+// AuditCleanupTask 审计日志清理任务，每天凌晨删除超过保留天数的审计日志
+func AuditCleanupTask() {
+	if config.Cfg.Audit.RetentionDays <= 0 {
+		return
+	}
+
+	// 启动时先清理一次
+	performAuditCleanup()
+
+	c := cron.New()
+	_, err := c.AddFunc("0 3 * * *", performAuditCleanup)
+	if err != nil {
+		slog.Errorf("添加审计日志清理任务失败: %v", err)
+		return
+	}
+
+	c.Start()
+	slog.Infof("AuditCleanupTask 定时任务已启动，审计日志保留 %d 天", config.Cfg.Audit.RetentionDays)
+}
+
+// performAuditCleanup 删除超过保留天数的审计日志
+func performAuditCleanup() {
+	before := time.Now().AddDate(0, 0, -config.Cfg.Audit.RetentionDays)
+	count, err := dal.DeleteAuditLogsBefore(before)
+	if err != nil {
+		slog.Errorf("清理审计日志失败: %v", err)
+		return
+	}
+	if count > 0 {
+		slog.Infof("已清理 %d 条过期的审计日志", count)
+	}
+}
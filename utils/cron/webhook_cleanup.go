@@ -0,0 +1,43 @@
+package cron
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/utils/config"
+	"time"
+
+	"github.com/gookit/slog"
+	"github.com/robfig/cron/v3"
+)
+
+// WebhookCleanupTask Webhook投递记录清理任务，每天凌晨删除超过保留天数的投递记录
+func WebhookCleanupTask() {
+	if config.Cfg.Webhook.RetentionDays <= 0 {
+		return
+	}
+
+	// 启动时先清理一次
+	performWebhookCleanup()
+
+	c := cron.New()
+	_, err := c.AddFunc("10 3 * * *", performWebhookCleanup)
+	if err != nil {
+		slog.Errorf("添加Webhook投递记录清理任务失败: %v", err)
+		return
+	}
+
+	c.Start()
+	slog.Infof("WebhookCleanupTask 定时任务已启动，投递记录保留 %d 天", config.Cfg.Webhook.RetentionDays)
+}
+
+// performWebhookCleanup 删除超过保留天数的投递记录
+func performWebhookCleanup() {
+	before := time.Now().AddDate(0, 0, -config.Cfg.Webhook.RetentionDays)
+	count, err := dal.DeleteWebhookDeliveriesBefore(before)
+	if err != nil {
+		slog.Errorf("清理Webhook投递记录失败: %v", err)
+		return
+	}
+	if count > 0 {
+		slog.Infof("已清理 %d 条过期的Webhook投递记录", count)
+	}
+}
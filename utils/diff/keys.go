@@ -0,0 +1,101 @@
+package diff
+
+import (
+	"confkeeper/utils/config_parser"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// KeyChange 单个键的变更
+type KeyChange struct {
+	Key  string `json:"key"`
+	From any    `json:"from,omitempty"`
+	To   any    `json:"to,omitempty"`
+}
+
+// KeyDiff 键级别差异
+type KeyDiff struct {
+	Added   []*KeyChange `json:"added"`
+	Removed []*KeyChange `json:"removed"`
+	Changed []*KeyChange `json:"changed"`
+}
+
+// Keys 解析两份结构化配置并比较键级别差异
+func Keys(configType string, from string, to string) (*KeyDiff, error) {
+	fromData, err := config_parser.Parse(configType, from)
+	if err != nil {
+		return nil, fmt.Errorf("解析旧版本失败: %v", err)
+	}
+	toData, err := config_parser.Parse(configType, to)
+	if err != nil {
+		return nil, fmt.Errorf("解析新版本失败: %v", err)
+	}
+
+	fromFlat := make(map[string]any)
+	toFlat := make(map[string]any)
+	flatten("", fromData, fromFlat)
+	flatten("", toData, toFlat)
+
+	result := &KeyDiff{
+		Added:   []*KeyChange{},
+		Removed: []*KeyChange{},
+		Changed: []*KeyChange{},
+	}
+	for _, key := range sortedKeys(fromFlat) {
+		toValue, ok := toFlat[key]
+		if !ok {
+			result.Removed = append(result.Removed, &KeyChange{Key: key, From: fromFlat[key]})
+			continue
+		}
+		if !reflect.DeepEqual(fromFlat[key], toValue) {
+			result.Changed = append(result.Changed, &KeyChange{Key: key, From: fromFlat[key], To: toValue})
+		}
+	}
+	for _, key := range sortedKeys(toFlat) {
+		if _, ok := fromFlat[key]; !ok {
+			result.Added = append(result.Added, &KeyChange{Key: key, To: toFlat[key]})
+		}
+	}
+	return result, nil
+}
+
+// flatten 将嵌套结构展开为 a.b[0].c 形式的键
+func flatten(prefix string, data any, out map[string]any) {
+	switch v := data.(type) {
+	case map[string]any:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = v
+			return
+		}
+		for key, item := range v {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flatten(key, item, out)
+		}
+	case []any:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = v
+			return
+		}
+		for i, item := range v {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), item, out)
+		}
+	case nil:
+		if prefix != "" {
+			out[prefix] = nil
+		}
+	default:
+		out[prefix] = v
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
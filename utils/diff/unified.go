@@ -0,0 +1,253 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// 默认上下文行数
+	defaultContext = 3
+	// Myers 算法的最大编辑距离，回溯需要保存每一步的 v，内存占用为编辑距离的平方
+	// 超过时不再计算最短编辑序列，直接按整体删除再插入输出
+	maxEditDistance = 1000
+)
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type lineOp struct {
+	Kind opKind
+	Text string
+	// 在原文件/新文件中的行号(从0开始)
+	A int
+	B int
+}
+
+// Unified 生成两个文本的统一格式(unified)差异
+// 内容相同时返回空字符串
+func Unified(fromName string, toName string, from string, to string) string {
+	a := splitLines(from)
+	b := splitLines(to)
+	ops := diffLines(a, b)
+
+	hunks := groupHunks(ops, defaultContext)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n", fromName)
+	fmt.Fprintf(&sb, "+++ %s\n", toName)
+	for _, hunk := range hunks {
+		writeHunk(&sb, hunk)
+	}
+	return sb.String()
+}
+
+// splitLines 按行拆分，忽略末尾换行产生的空行
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.TrimSuffix(s, "\n")
+	return strings.Split(s, "\n")
+}
+
+// diffLines 去掉相同的首尾行后，使用 Myers 算法计算中间部分的最短编辑序列
+func diffLines(a []string, b []string) []lineOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]lineOp, 0, len(a)+len(b)-prefix-suffix)
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, lineOp{Kind: opEqual, Text: a[i], A: i, B: i})
+	}
+
+	middle, ok := myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	if !ok {
+		middle = replaceAll(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	}
+	for _, op := range middle {
+		op.A += prefix
+		op.B += prefix
+		ops = append(ops, op)
+	}
+
+	for i := suffix; i > 0; i-- {
+		x, y := len(a)-i, len(b)-i
+		ops = append(ops, lineOp{Kind: opEqual, Text: a[x], A: x, B: y})
+	}
+	return ops
+}
+
+// replaceAll 整体删除 a 再插入 b
+func replaceAll(a []string, b []string) []lineOp {
+	ops := make([]lineOp, 0, len(a)+len(b))
+	for x, text := range a {
+		ops = append(ops, lineOp{Kind: opDelete, Text: text, A: x, B: 0})
+	}
+	for y, text := range b {
+		ops = append(ops, lineOp{Kind: opInsert, Text: text, A: len(a), B: y})
+	}
+	return ops
+}
+
+// myers 使用 Myers 算法计算最短编辑序列，编辑距离超过 maxEditDistance 时返回 false
+func myers(a []string, b []string) ([]lineOp, bool) {
+	n, m := len(a), len(b)
+	maxD := min(n+m, maxEditDistance)
+	if n+m == 0 {
+		return nil, true
+	}
+
+	// v[k] 表示对角线 k 上能到达的最远 x，trace[d] 保存第 d 步开始前 [-d, d] 区间的 v
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	var trace [][]int
+
+	finalD := -1
+search:
+	for d := 0; d <= maxD; d++ {
+		snapshot := make([]int, 2*d+3)
+		copy(snapshot, v[offset-d-1:offset+d+2])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				finalD = d
+				break search
+			}
+		}
+	}
+
+	if finalD < 0 {
+		return nil, false
+	}
+
+	// 回溯得到编辑序列(倒序)
+	var ops []lineOp
+	x, y := n, m
+	for d := finalD; d >= 0; d-- {
+		snapshot := trace[d]
+		at := func(k int) int { return snapshot[k+d+1] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, lineOp{Kind: opEqual, Text: a[x], A: x, B: y})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				ops = append(ops, lineOp{Kind: opInsert, Text: b[y], A: x, B: y})
+			} else {
+				x--
+				ops = append(ops, lineOp{Kind: opDelete, Text: a[x], A: x, B: y})
+			}
+		}
+	}
+
+	// 反转为正序
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops, true
+}
+
+// groupHunks 将编辑序列按上下文行数分组
+func groupHunks(ops []lineOp, context int) [][]lineOp {
+	var hunks [][]lineOp
+	start, end := -1, -1
+
+	for i, op := range ops {
+		if op.Kind == opEqual {
+			continue
+		}
+		lo := max(0, i-context)
+		hi := min(len(ops), i+context+1)
+		if start >= 0 && lo <= end {
+			end = hi
+			continue
+		}
+		if start >= 0 {
+			hunks = append(hunks, ops[start:end])
+		}
+		start, end = lo, hi
+	}
+	if start >= 0 {
+		hunks = append(hunks, ops[start:end])
+	}
+	return hunks
+}
+
+// writeHunk 输出单个差异块
+func writeHunk(sb *strings.Builder, hunk []lineOp) {
+	aStart, bStart := hunk[0].A, hunk[0].B
+	var aCount, bCount int
+	for _, op := range hunk {
+		switch op.Kind {
+		case opEqual:
+			aCount++
+			bCount++
+		case opDelete:
+			aCount++
+		case opInsert:
+			bCount++
+		}
+	}
+
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+	for _, op := range hunk {
+		sb.WriteByte(byte(op.Kind))
+		sb.WriteString(op.Text)
+		sb.WriteByte('\n')
+	}
+}
+
+// hunkRange 按 GNU diff 规则格式化行号范围
+func hunkRange(start int, count int) string {
+	switch count {
+	case 0:
+		// 空范围时行号指向前一行
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
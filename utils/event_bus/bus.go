@@ -0,0 +1,88 @@
+package event_bus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gookit/slog"
+)
+
+// 配置变更事件类型
+const (
+	// EventPublish 发布了新版本，包括新建、更新、回滚、克隆、导入和灰度转正
+	EventPublish = "publish"
+	// EventDelete 删除了配置的所有版本
+	EventDelete = "delete"
+	// EventBeta 发布或终止了灰度版本
+	EventBeta = "beta"
+)
+
+// ConfigEvent 配置变更事件
+type ConfigEvent struct {
+	Type     string
+	TenantID string
+	GroupID  string
+	DataID   string
+	// 新版本的版本号和md5，只有发布事件才有
+	Version int
+	Md5     string
+	// 修改人，只有发布事件才有
+	Author string
+	Time   time.Time
+}
+
+var (
+	// 值为通道已满丢弃事件时是否打印日志
+	subscribers = make(map[chan ConfigEvent]bool)
+	subLock     sync.RWMutex
+)
+
+// Subscribe 订阅配置变更事件，返回事件通道和取消订阅函数
+func Subscribe() (<-chan ConfigEvent, func()) {
+	return subscribe(64, false)
+}
+
+// SubscribeWithBuffer 指定事件通道的缓冲大小订阅，用于不能丢弃事件的长期订阅者，通道已满丢弃事件时打印日志
+func SubscribeWithBuffer(size int) (<-chan ConfigEvent, func()) {
+	return subscribe(size, true)
+}
+
+func subscribe(size int, logDropped bool) (<-chan ConfigEvent, func()) {
+	ch := make(chan ConfigEvent, size)
+
+	subLock.Lock()
+	subscribers[ch] = logDropped
+	subLock.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			subLock.Lock()
+			delete(subscribers, ch)
+			subLock.Unlock()
+		})
+	}
+
+	return ch, cancel
+}
+
+// Publish 发布配置变更事件
+// 订阅者通道已满时丢弃事件，避免阻塞写入流程（订阅者需自行兜底重新比对）
+func Publish(event ConfigEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	subLock.RLock()
+	defer subLock.RUnlock()
+
+	for ch, logDropped := range subscribers {
+		select {
+		case ch <- event:
+		default:
+			if logDropped {
+				slog.Warnf("事件通道已满，丢弃配置变更事件: %s %s/%s/%s", event.Type, event.TenantID, event.GroupID, event.DataID)
+			}
+		}
+	}
+}
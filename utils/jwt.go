@@ -2,15 +2,15 @@ package utils
 
 import (
 	"confkeeper/utils/config"
+	"confkeeper/utils/session"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"time"
 
-	"sync"
-
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/gookit/slog"
 )
 
 var (
@@ -25,13 +25,30 @@ var (
 		IdentityKey:   "userid",
 		TokenHeadName: "Bearer",
 	}
+)
 
-	// TokenStore 用于内存存储 userid -> token
-	TokenStore sync.Map
-	TokenLock  sync.Mutex
+const (
+	tokenTypeAccess    = "access"
+	tokenTypeShortTerm = "short_term"
+	tokenTypeRefresh   = "refresh"
+	tokenTypeMfa       = "mfa"
+
+	// 未配置 access_expire_time 时 access token 的有效期
+	defaultAccessExpireTime = 15 * time.Minute
+	// 密码校验通过后完成两步验证的时限
+	mfaExpireTime = 5 * time.Minute
 )
 
-// GenerateToken 生成 JWT Token
+// TokenPair 登录或刷新后签发的 token
+// 同一次登录签发的 token 属于同一个家族(claim fid)，家族ID即会话ID，吊销会话时整个家族失效
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	// access token 有效期(秒)
+	ExpiresIn int64
+}
+
+// GenerateToken 生成不可刷新的 JWT Token，expTime 单位为分钟
 func GenerateToken(userid uint, username string, expTime ...int) (string, error) {
 	// 设置过期时间
 	var expireTime time.Duration
@@ -41,66 +58,222 @@ func GenerateToken(userid uint, username string, expTime ...int) (string, error)
 		expireTime = time.Hour * time.Duration(config.Cfg.Jwt.ExpireTime)
 	}
 
-	// 创建 claims
-	claims := jwt.MapClaims{
-		"userid":     int(userid),
-		"username":   username,
-		"iss":        config.Cfg.Server.Name,
-		"exp":        time.Now().Add(expireTime).Unix(),
-		"orig_iat":   time.Now().Unix(),
-		"jti":        fmt.Sprintf("%d", time.Now().UnixNano()), // 确保唯一性
-		"token_type": "access",                                 // 默认为访问令牌
-	}
+	now := time.Now()
+	expireAt := now.Add(expireTime)
 
-	// 如果是短期令牌，添加标识
+	tokenType := tokenTypeAccess                              // 默认为访问令牌
 	if len(expTime) > 0 && expTime[0] > 0 && expTime[0] < 5 { // 小于5分钟的认为是短期令牌
-		claims["token_type"] = "short_term"
+		tokenType = tokenTypeShortTerm
 	}
 
-	// 生成 token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtConfig.Secret)
+	familyID := newTokenID()
+	tokenString, err := signToken(userid, username, tokenType, familyID, newTokenID(), now, expireAt)
 	if err != nil {
 		return "", err
 	}
 
-	// 如果启用了内存存储，则将 token存储到内存中
-	if config.Cfg.Jwt.EnableMemory {
-		TokenLock.Lock()
-		defer TokenLock.Unlock()
+	if err := addSession(int(userid), familyID, expireAt); err != nil {
+		return "", err
+	}
+	return tokenString, nil
+}
 
-		maxSessions := config.Cfg.Jwt.MaxLoginSessions
-		if maxSessions <= 0 {
-			maxSessions = 1 // 如果未设置或无效，默认为1
-		}
+// GenerateTokenPair 登录时签发短期的 access token 和可轮换的 refresh token
+// sessionTime 为会话最长有效期，refresh token 轮换时不会延长，到期后需要重新登录
+func GenerateTokenPair(userid uint, username string, sessionTime time.Duration) (*TokenPair, error) {
+	now := time.Now()
+	expireAt := now.Add(sessionTime)
+	familyID := newTokenID()
+	refreshJti := newTokenID()
 
-		var tokens []string
-		if existingTokens, ok := TokenStore.Load(int(userid)); ok {
-			if strTokens, ok := existingTokens.([]string); ok {
-				// 复制切片以避免修改 Map 中的切片（尽管使用 store 是安全的）
-				// 但显式复制更安全
-				tokens = make([]string, len(strTokens))
-				copy(tokens, strTokens)
-			}
-		}
+	pair, err := signTokenPair(userid, username, familyID, refreshJti, now, expireAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := addSession(int(userid), familyID, expireAt); err != nil {
+		return nil, err
+	}
+	if err := session.Default.SaveRefresh(familyID, refreshJti, expireAt); err != nil {
+		return nil, fmt.Errorf("保存refresh token失败: %v", err)
+	}
+	return pair, nil
+}
+
+// RefreshTokenPair 使用 refresh token 换取新的 token，旧的 refresh token 随即失效
+// 已失效的 refresh token 被再次使用时视为泄露，吊销整个 token 家族
+func RefreshTokenPair(refreshToken string) (*TokenPair, error) {
+	claims, err := ParseToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if tokenType, _ := claims["token_type"].(string); tokenType != tokenTypeRefresh {
+		return nil, fmt.Errorf("令牌类型错误，需要refresh token")
+	}
 
-		// 添加新 token
-		tokens = append(tokens, tokenString)
+	userid, _ := claims["userid"].(int)
+	username, _ := claims["username"].(string)
+	familyID, _ := claims["fid"].(string)
+	jti, _ := claims["jti"].(string)
+	origIat, _ := claims["orig_iat"].(float64)
+	exp, _ := claims["exp"].(float64)
 
-		// 强制限制：如果超过限制，删除最旧的
-		if len(tokens) > maxSessions {
-			// 保留最后 maxSessions 个 token
-			tokens = tokens[len(tokens)-maxSessions:]
+	newJti := newTokenID()
+	rotated, err := session.Default.RotateRefresh(familyID, jti, newJti)
+	if err != nil {
+		return nil, fmt.Errorf("服务器内部错误: 轮换refresh token失败")
+	}
+	if !rotated {
+		if err := revokeSession(userid, familyID); err != nil {
+			return nil, fmt.Errorf("服务器内部错误: 吊销会话失败")
 		}
+		return nil, fmt.Errorf("refresh token 已被使用，会话已吊销，请重新登录")
+	}
+
+	return signTokenPair(uint(userid), username, familyID, newJti, time.Unix(int64(origIat), 0), time.Unix(int64(exp), 0))
+}
 
-		TokenStore.Store(int(userid), tokens)
+// GenerateMfaToken 密码校验通过但需要两步验证时签发的临时令牌，只能用于完成登录，不会保存会话
+func GenerateMfaToken(userid uint, username string, rememberMe bool) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"userid":      int(userid),
+		"username":    username,
+		"iss":         config.Cfg.Server.Name,
+		"exp":         now.Add(mfaExpireTime).Unix(),
+		"orig_iat":    now.Unix(),
+		"jti":         newTokenID(),
+		"token_type":  tokenTypeMfa,
+		"remember_me": rememberMe,
 	}
 
-	return tokenString, nil
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtConfig.Secret)
+}
+
+// ParseMfaToken 解析两步验证临时令牌
+func ParseMfaToken(tokenStr string) (userid int, rememberMe bool, err error) {
+	claims, err := parseClaims(tokenStr)
+	if err != nil {
+		return 0, false, err
+	}
+	if tokenType, _ := claims["token_type"].(string); tokenType != tokenTypeMfa {
+		return 0, false, fmt.Errorf("令牌类型错误，需要两步验证令牌")
+	}
+
+	userid, _ = claims["userid"].(int)
+	rememberMe, _ = claims["remember_me"].(bool)
+	return userid, rememberMe, nil
+}
+
+// signTokenPair 签发同一家族的 access token 和 refresh token，access token 不会晚于会话过期
+func signTokenPair(userid uint, username string, familyID string, refreshJti string, origIat time.Time, expireAt time.Time) (*TokenPair, error) {
+	accessExpireTime := time.Minute * time.Duration(config.Cfg.Jwt.AccessExpireTime)
+	if accessExpireTime <= 0 {
+		accessExpireTime = defaultAccessExpireTime
+	}
+	accessExpireAt := time.Now().Add(accessExpireTime)
+	if accessExpireAt.After(expireAt) {
+		accessExpireAt = expireAt
+	}
+
+	accessToken, err := signToken(userid, username, tokenTypeAccess, familyID, newTokenID(), origIat, accessExpireAt)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := signToken(userid, username, tokenTypeRefresh, familyID, refreshJti, origIat, expireAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(time.Until(accessExpireAt).Seconds()),
+	}, nil
+}
+
+func signToken(userid uint, username string, tokenType string, familyID string, jti string, origIat time.Time, expireAt time.Time) (string, error) {
+	// 创建 claims
+	claims := jwt.MapClaims{
+		"userid":     int(userid),
+		"username":   username,
+		"iss":        config.Cfg.Server.Name,
+		"exp":        expireAt.Unix(),
+		"orig_iat":   origIat.Unix(),         // 登录时间，刷新时保持不变
+		"iat_ms":     time.Now().UnixMilli(), // 精确的签发时间，用于按时间吊销
+		"jti":        jti,
+		"fid":        familyID,
+		"token_type": tokenType,
+	}
+
+	// 生成 token
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtConfig.Secret)
+}
+
+// addSession 如果启用了会话存储，则将会话保存到会话存储中
+func addSession(userid int, familyID string, expireAt time.Time) error {
+	if !config.Cfg.Jwt.EnableMemory {
+		return nil
+	}
+
+	maxSessions := config.Cfg.Jwt.MaxLoginSessions
+	if maxSessions <= 0 {
+		maxSessions = 1 // 如果未设置或无效，默认为1
+	}
+
+	if err := session.Default.Add(userid, familyID, expireAt, maxSessions); err != nil {
+		return fmt.Errorf("保存会话失败: %v", err)
+	}
+	return nil
+}
+
+// newTokenID 生成随机的 jti 和家族ID
+func newTokenID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// 随机数生成失败时退化为时间戳，仍能保证唯一性
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
 }
 
 // ParseToken 解析并验证 JWT Token
 func ParseToken(tokenStr string) (jwt.MapClaims, error) {
+	claims, err := parseClaims(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	userid, _ := claims["userid"].(int)
+
+	// 如果启用了会话存储，验证会话是否有效
+	sessionID := tokenSessionID(claims, tokenStr)
+	if config.Cfg.Jwt.EnableMemory {
+		exists, err := session.Default.Exists(userid, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("服务器内部错误: 查询会话失败")
+		}
+		if !exists {
+			// 未找到会话（例如用户登录次数过多，旧会话已被淘汰）
+			return nil, fmt.Errorf("令牌无效或已失效（可能是异地登录导致），请重新登录")
+		}
+	} else {
+		// 未启用会话存储时检查黑名单
+		revoked, err := session.Default.IsRevoked(userid, sessionID, issuedAt(claims))
+		if err != nil {
+			return nil, fmt.Errorf("服务器内部错误: 查询token黑名单失败")
+		}
+		if revoked {
+			return nil, fmt.Errorf("令牌已被吊销，请重新登录")
+		}
+	}
+
+	return claims, nil
+}
+
+// parseClaims 校验 token 签名、issuer 和过期时间，不检查会话
+func parseClaims(tokenStr string) (jwt.MapClaims, error) {
 	// 解析 token
 	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -153,14 +326,8 @@ func ParseToken(tokenStr string) (jwt.MapClaims, error) {
 	}
 
 	// userid 转换为 int
-	var userid int
-	if useridVal, ok := claims["userid"]; ok {
-		if uid, ok := useridVal.(float64); ok {
-			userid = int(uid)
-			claims["userid"] = userid
-		} else if uid, ok := useridVal.(int); ok {
-			userid = uid
-		}
+	if uid, ok := claims["userid"].(float64); ok {
+		claims["userid"] = int(uid)
 	}
 
 	// 验证 username
@@ -168,33 +335,66 @@ func ParseToken(tokenStr string) (jwt.MapClaims, error) {
 		return nil, fmt.Errorf("token 缺少 username")
 	}
 
-	// 如果启用了内存存储，验证是否在内存中
+	return claims, nil
+}
+
+// issuedAt 获取 token 的签发时间，旧 token 没有 iat_ms 时使用秒级的 orig_iat
+func issuedAt(claims jwt.MapClaims) time.Time {
+	if iatMs, ok := claims["iat_ms"].(float64); ok {
+		return time.UnixMilli(int64(iatMs))
+	}
+	iat, _ := claims["orig_iat"].(float64)
+	return time.Unix(int64(iat), 0)
+}
+
+// tokenSessionID 获取 token 所属的会话ID
+// 旧版本签发的 token 没有家族ID，启用会话存储时以 token 本身、否则以 jti 作为会话ID
+func tokenSessionID(claims jwt.MapClaims, tokenStr string) string {
+	if familyID, ok := claims["fid"].(string); ok && familyID != "" {
+		return familyID
+	}
 	if config.Cfg.Jwt.EnableMemory {
-		if storedTokens, ok := TokenStore.Load(userid); ok {
-			if tokenList, ok := storedTokens.([]string); ok {
-				found := false
-				for _, t := range tokenList {
-					if t == tokenStr {
-						found = true
-						break
-					}
-				}
-				if !found {
-					// 在有效列表中未找到 Token（例如用户登录次数过多，旧会话已删除）
-					return nil, fmt.Errorf("令牌无效或已失效（可能是异地登录导致），请重新登录")
-				}
-			} else {
-				//如果类型断言失败，可能会发生这种情况，例如代码重启后类型不兼容
-				// 或者逻辑错误。拒绝是最安全的做法。
-				return nil, fmt.Errorf("服务器内部错误: 令牌存储格式错误")
-			}
-		} else {
-			// 在内存中未找到此用户的 Token（可能是服务器重启或从未使用此用户登录）
-			return nil, fmt.Errorf("令牌不存在或已失效，请重新登录")
-		}
+		return tokenStr
 	}
+	jti, _ := claims["jti"].(string)
+	return jti
+}
 
-	return claims, nil
+// maxSessionTime 会话最长有效期，登录时不勾选记住我的会话有效期为1小时
+func maxSessionTime() time.Duration {
+	return max(time.Hour*time.Duration(config.Cfg.Jwt.ExpireTime), time.Hour)
+}
+
+// RevokeToken 吊销 token 所属的会话，同一次登录签发的 access token 和 refresh token 一并失效
+func RevokeToken(tokenStr string) error {
+	claims, err := ParseToken(tokenStr)
+	if err != nil {
+		return err
+	}
+
+	userid, _ := claims["userid"].(int)
+	return revokeSession(userid, tokenSessionID(claims, tokenStr))
+}
+
+// revokeSession 启用会话存储时删除会话，否则将会话ID加入黑名单
+func revokeSession(userid int, sessionID string) error {
+	if config.Cfg.Jwt.EnableMemory {
+		return session.Default.Remove(userid, sessionID)
+	}
+
+	// 黑名单保留到该会话签发的 token 全部过期
+	return session.Default.Revoke(sessionID, time.Now().Add(maxSessionTime()))
+}
+
+// RevokeUserTokens 吊销用户已签发的所有 token
+func RevokeUserTokens(userid int) error {
+	if config.Cfg.Jwt.EnableMemory {
+		return session.Default.RemoveAll(userid)
+	}
+
+	// 黑名单保留到此前签发的 token 全部过期
+	now := time.Now()
+	return session.Default.RevokeUser(userid, now, now.Add(maxSessionTime()))
 }
 
 // GetUsernameFromContext 从上下文中提取用户名
@@ -227,21 +427,6 @@ func GetUseridFromContext(c *gin.Context) (int, error) {
 	return userid, nil
 }
 
-// IsAdmin 判断是否为管理员
-func IsAdmin(c *gin.Context) error {
-	userid, err := GetUseridFromContext(c)
-	if err != nil {
-		return err
-	}
-
-	if userid != 1 {
-		slog.Infof("当前用户ID: %d", userid)
-		return fmt.Errorf("不是管理员，没有权限")
-	}
-
-	return nil
-}
-
 func ValidateShortTermToken(c *gin.Context, token string) error {
 	// 验证 token
 	claims, err := ParseToken(token)
@@ -250,7 +435,7 @@ func ValidateShortTermToken(c *gin.Context, token string) error {
 	}
 	// 检查短时token
 	tokenType, ok := claims["token_type"].(string)
-	if !ok || tokenType != "short_term" {
+	if !ok || tokenType != tokenTypeShortTerm {
 		return fmt.Errorf("没有权限")
 	}
 
@@ -3,15 +3,102 @@ package ldap_client
 import (
 	"confkeeper/utils/config"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/go-ldap/ldap/v3"
 	"github.com/gookit/slog"
 )
 
-func LDAPAuth(username, password string) (bool, map[string]string, error) {
-	var l *ldap.Conn
-	var err error
+const (
+	GroupTypeMemberOf     = "memberof"
+	GroupTypeGroupOfNames = "groupofnames"
+
+	defaultUserFilter    = "(uid=%s)"
+	defaultGroupFilter   = "(&(objectClass=groupOfNames)(member=%s))"
+	defaultGroupNameAttr = "cn"
+
+	dialTimeout = 10 * time.Second
+)
+
+var (
+	// ErrUserNotFound LDAP 中没有该用户或找到多个用户
+	ErrUserNotFound = errors.New("未找到用户或找到多个用户")
+	// ErrInvalidCredentials 找到了用户但密码错误
+	ErrInvalidCredentials = errors.New("LDAP认证失败")
+)
+
+// User LDAP 认证通过的用户
+type User struct {
+	DN         string
+	Attributes map[string]string
+	// 用户所在的组，同时包含组的完整DN和组名，未配置 group_type 时为空
+	Groups []string
+}
+
+func LDAPAuth(username, password string) (bool, *User, error) {
+	// 空密码会被服务端当作匿名绑定而成功
+	if username == "" || password == "" {
+		return false, nil, ErrInvalidCredentials
+	}
+
+	l, err := connect()
+	if err != nil {
+		return false, nil, err
+	}
+	defer l.Close()
+
+	entry, err := searchUser(l, username)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if err = l.Bind(entry.DN, password); err != nil {
+		return false, nil, ErrInvalidCredentials
+	}
+
+	user, err := newUser(l, entry)
+	if err != nil {
+		return false, nil, err
+	}
+	return true, user, nil
+}
+
+// CheckConnection 使用当前配置连接服务器并完成服务账号绑定，用于检查配置是否正确
+// username 不为空时搜索该用户及其所在的组，password 不为空时同时校验用户密码
+func CheckConnection(username, password string) (*User, error) {
+	l, err := connect()
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+
+	if username == "" {
+		return nil, nil
+	}
+
+	entry, err := searchUser(l, username)
+	if err != nil {
+		return nil, err
+	}
+	if password != "" {
+		if err = l.Bind(entry.DN, password); err != nil {
+			return nil, fmt.Errorf("LDAP认证失败: %v", err)
+		}
+	}
+	return newUser(l, entry)
+}
+
+// connect 建立连接，按配置使用 LDAPS 或 StartTLS，并完成服务账号绑定
+func connect() (*ldap.Conn, error) {
+	if config.Cfg.Ldap.TLS && config.Cfg.Ldap.StartTLS {
+		return nil, errors.New("LDAP配置错误: tls 和 start_tls 不能同时开启")
+	}
 
 	scheme := "ldap"
 	if config.Cfg.Ldap.TLS {
@@ -19,59 +106,211 @@ func LDAPAuth(username, password string) (bool, map[string]string, error) {
 	}
 	ldapURL := fmt.Sprintf("%s://%s", scheme, config.Cfg.Ldap.Addr)
 
-	var opts []ldap.DialOpt
+	opts := []ldap.DialOpt{ldap.DialWithDialer(&net.Dialer{Timeout: dialTimeout})}
+	var tlsConfig *tls.Config
+	if config.Cfg.Ldap.TLS || config.Cfg.Ldap.StartTLS {
+		var err error
+		if tlsConfig, err = newTLSConfig(); err != nil {
+			return nil, err
+		}
+	}
 	if config.Cfg.Ldap.TLS {
-		opts = append(opts, ldap.DialWithTLSConfig(&tls.Config{InsecureSkipVerify: true}))
+		opts = append(opts, ldap.DialWithTLSConfig(tlsConfig))
 	}
 
-	l, err = ldap.DialURL(ldapURL, opts...)
+	l, err := ldap.DialURL(ldapURL, opts...)
 	if err != nil {
 		slog.Errorf("LDAP连接失败: %v", err)
-		return false, nil, fmt.Errorf("LDAP连接失败")
+		return nil, fmt.Errorf("LDAP连接失败: %v", err)
 	}
-	defer l.Close()
 
-	// Bind
-	if config.Cfg.Ldap.BindDN != "" {
-		err = l.Bind(config.Cfg.Ldap.BindDN, config.Cfg.Ldap.BindPass)
-		slog.Errorf("LDAP绑定鉴权失败: %v", err)
+	if config.Cfg.Ldap.StartTLS {
+		if err = l.StartTLS(tlsConfig); err != nil {
+			l.Close()
+			slog.Errorf("LDAP StartTLS 失败: %v", err)
+			return nil, fmt.Errorf("LDAP StartTLS 失败: %v", err)
+		}
+	}
+
+	if err = bindServiceAccount(l); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// newTLSConfig 校验服务器证书，配置了 ca_cert 时只信任其中的CA，server_name 为空时使用 addr 中的主机名
+func newTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         config.Cfg.Ldap.ServerName,
+		InsecureSkipVerify: config.Cfg.Ldap.InsecureSkipVerify,
+	}
+	if tlsConfig.ServerName == "" {
+		host, _, err := net.SplitHostPort(config.Cfg.Ldap.Addr)
+		if err != nil {
+			host = config.Cfg.Ldap.Addr
+		}
+		tlsConfig.ServerName = host
+	}
+
+	if config.Cfg.Ldap.CACert != "" {
+		pem, err := os.ReadFile(config.Cfg.Ldap.CACert)
 		if err != nil {
-			return false, nil, fmt.Errorf("LDAP绑定鉴权失败")
+			return nil, fmt.Errorf("读取LDAP CA证书失败: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("LDAP CA证书中没有有效的证书: %s", config.Cfg.Ldap.CACert)
 		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// bindServiceAccount 配置了 bind_dn 时使用服务账号绑定
+func bindServiceAccount(l *ldap.Conn) error {
+	if config.Cfg.Ldap.BindDN == "" {
+		return nil
+	}
+	if err := l.Bind(config.Cfg.Ldap.BindDN, config.Cfg.Ldap.BindPass); err != nil {
+		slog.Errorf("LDAP绑定鉴权失败: %v", err)
+		return fmt.Errorf("LDAP绑定鉴权失败")
+	}
+	return nil
+}
+
+// searchUser 按 user_filter 搜索用户，必须且只能找到一个
+func searchUser(l *ldap.Conn, username string) (*ldap.Entry, error) {
+	userFilter := config.Cfg.Ldap.UserFilter
+	if userFilter == "" {
+		userFilter = defaultUserFilter
+	}
+	filter, err := formatFilter(userFilter, username)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := []string{"dn", "cn", "mail", "uid"}
+	if strings.ToLower(config.Cfg.Ldap.GroupType) == GroupTypeMemberOf {
+		attributes = append(attributes, "memberOf")
 	}
 
-	// Search
-	filter := fmt.Sprintf("(uid=%s)", username)
 	searchReq := ldap.NewSearchRequest(
 		config.Cfg.Ldap.BaseDN,
 		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 		filter,
-		[]string{"dn", "cn", "mail", "uid"},
+		attributes,
 		nil,
 	)
 
 	sr, err := l.Search(searchReq)
 	if err != nil {
 		slog.Errorf("LDAP搜索失败: %v", err)
-		return false, nil, fmt.Errorf("LDAP搜索失败")
+		return nil, fmt.Errorf("LDAP搜索失败")
 	}
 
 	if len(sr.Entries) != 1 {
-		return false, nil, fmt.Errorf("未找到用户或找到多个用户")
+		return nil, ErrUserNotFound
 	}
+	return sr.Entries[0], nil
+}
 
-	userDN := sr.Entries[0].DN
-	err = l.Bind(userDN, password)
-	if err != nil {
-		return false, nil, fmt.Errorf("LDAP认证失败")
+// newUser 读取用户属性和所在的组
+func newUser(l *ldap.Conn, entry *ldap.Entry) (*User, error) {
+	user := &User{
+		DN:         entry.DN,
+		Attributes: make(map[string]string),
 	}
-
-	userInfo := make(map[string]string)
-	for _, attr := range sr.Entries[0].Attributes {
+	for _, attr := range entry.Attributes {
 		if len(attr.Values) > 0 {
-			userInfo[attr.Name] = attr.Values[0]
+			user.Attributes[attr.Name] = attr.Values[0]
 		}
 	}
 
-	return true, userInfo, nil
+	var err error
+	switch strings.ToLower(config.Cfg.Ldap.GroupType) {
+	case "":
+	case GroupTypeMemberOf:
+		for _, groupDN := range entry.GetAttributeValues("memberOf") {
+			user.Groups = appendGroup(user.Groups, groupDN, "")
+		}
+	case GroupTypeGroupOfNames:
+		if user.Groups, err = searchGroups(l, entry.DN); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("不支持的LDAP用户组查询方式: %s", config.Cfg.Ldap.GroupType)
+	}
+	return user, nil
+}
+
+// formatFilter 把过滤器模板中的 %s 替换为转义后的值，防止构造过滤器注入
+func formatFilter(template string, value string) (string, error) {
+	if !strings.Contains(template, "%s") {
+		return "", fmt.Errorf("LDAP过滤器缺少 %%s: %s", template)
+	}
+	filter := strings.ReplaceAll(template, "%s", ldap.EscapeFilter(value))
+	if _, err := ldap.CompileFilter(filter); err != nil {
+		return "", fmt.Errorf("LDAP过滤器格式错误: %s", template)
+	}
+	return filter, nil
+}
+
+// searchGroups 搜索成员中包含用户DN的组
+func searchGroups(l *ldap.Conn, userDN string) ([]string, error) {
+	// 用户本身可能没有搜索组的权限，切换回服务账号
+	if err := bindServiceAccount(l); err != nil {
+		return nil, err
+	}
+
+	baseDN := config.Cfg.Ldap.GroupBaseDN
+	if baseDN == "" {
+		baseDN = config.Cfg.Ldap.BaseDN
+	}
+	groupFilter := config.Cfg.Ldap.GroupFilter
+	if groupFilter == "" {
+		groupFilter = defaultGroupFilter
+	}
+	filter, err := formatFilter(groupFilter, userDN)
+	if err != nil {
+		return nil, err
+	}
+	nameAttr := config.Cfg.Ldap.GroupNameAttr
+	if nameAttr == "" {
+		nameAttr = defaultGroupNameAttr
+	}
+
+	searchReq := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		[]string{nameAttr},
+		nil,
+	)
+
+	sr, err := l.Search(searchReq)
+	if err != nil {
+		slog.Errorf("LDAP用户组搜索失败: %v", err)
+		return nil, fmt.Errorf("LDAP用户组搜索失败")
+	}
+
+	var groups []string
+	for _, entry := range sr.Entries {
+		groups = appendGroup(groups, entry.DN, entry.GetAttributeValue(nameAttr))
+	}
+	return groups, nil
+}
+
+// appendGroup 同时记录组的完整DN和组名，组名为空时取DN的第一个RDN
+func appendGroup(groups []string, groupDN string, name string) []string {
+	if name == "" {
+		if dn, err := ldap.ParseDN(groupDN); err == nil && len(dn.RDNs) > 0 && len(dn.RDNs[0].Attributes) > 0 {
+			name = dn.RDNs[0].Attributes[0].Value
+		}
+	}
+	groups = append(groups, groupDN)
+	if name != "" {
+		groups = append(groups, name)
+	}
+	return groups
 }
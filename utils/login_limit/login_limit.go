@@ -0,0 +1,136 @@
+package login_limit
+
+import (
+	"confkeeper/utils/config"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLockTime    = time.Minute
+	defaultMaxLockTime = 30 * time.Minute
+
+	// 按IP记录的最大数量，超过时淘汰最久没有失败的记录
+	maxIPRecords = 100000
+)
+
+// LockDuration 连续失败 failures 次后的锁定时长
+// 未达到 maxAttempts 时不锁定，达到后锁定 lock_time，之后每多失败一次锁定时间翻倍，最长为 max_lock_time
+func LockDuration(failures int, maxAttempts int) time.Duration {
+	if maxAttempts <= 0 || failures < maxAttempts {
+		return 0
+	}
+
+	lockTime := time.Minute * time.Duration(config.Cfg.LoginLimit.LockTime)
+	if lockTime <= 0 {
+		lockTime = defaultLockTime
+	}
+	maxLockTime := ResetTime()
+
+	// 避免位移溢出
+	exponent := failures - maxAttempts
+	if exponent >= 32 || lockTime > maxLockTime>>exponent {
+		return maxLockTime
+	}
+	return lockTime << exponent
+}
+
+// ResetTime 超过该时间没有新的失败时清零失败次数
+func ResetTime() time.Duration {
+	maxLockTime := time.Minute * time.Duration(config.Cfg.LoginLimit.MaxLockTime)
+	if maxLockTime <= 0 {
+		maxLockTime = defaultMaxLockTime
+	}
+	return maxLockTime
+}
+
+// LockedError 返回锁定提示，剩余时间按分钟向上取整
+func LockedError(remaining time.Duration) error {
+	return fmt.Errorf("登录失败次数过多，请在%d分钟后重试", int(math.Ceil(remaining.Minutes())))
+}
+
+type ipRecord struct {
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+var (
+	// 按IP记录的连续失败，只保存在进程内存中
+	ipRecords   = make(map[string]*ipRecord)
+	ipLock      sync.Mutex
+	lastCleanup time.Time
+)
+
+// IPLockedFor 返回IP剩余的锁定时间，未锁定时为0
+func IPLockedFor(ip string) time.Duration {
+	ipLock.Lock()
+	defer ipLock.Unlock()
+
+	record, ok := ipRecords[ip]
+	if !ok {
+		return 0
+	}
+	return max(time.Until(record.lockedUntil), 0)
+}
+
+// RecordIPFailure 记录IP的一次登录失败
+func RecordIPFailure(ip string) {
+	maxAttempts := config.Cfg.LoginLimit.IpMaxAttempts
+	if maxAttempts <= 0 {
+		return
+	}
+
+	ipLock.Lock()
+	defer ipLock.Unlock()
+
+	now := time.Now()
+	cleanup(now)
+
+	record, ok := ipRecords[ip]
+	if !ok && len(ipRecords) >= maxIPRecords {
+		evict()
+	}
+	if !ok || now.Sub(record.lastFailure) > ResetTime() {
+		record = &ipRecord{}
+		ipRecords[ip] = record
+	}
+	record.failures++
+	record.lastFailure = now
+	if lockDuration := LockDuration(record.failures, maxAttempts); lockDuration > 0 {
+		record.lockedUntil = now.Add(lockDuration)
+	}
+}
+
+// cleanup 每分钟清理一次已经清零的记录，调用方需持有 ipLock
+func cleanup(now time.Time) {
+	if now.Sub(lastCleanup) < time.Minute {
+		return
+	}
+	lastCleanup = now
+
+	resetTime := ResetTime()
+	for ip, record := range ipRecords {
+		if now.Sub(record.lastFailure) > resetTime && now.After(record.lockedUntil) {
+			delete(ipRecords, ip)
+		}
+	}
+}
+
+// evict 淘汰最久没有失败的十分之一记录，调用方需持有 ipLock
+// 一次淘汰一批，避免记录数达到上限后每次记录都要遍历全部记录
+func evict() {
+	ips := make([]string, 0, len(ipRecords))
+	for ip := range ipRecords {
+		ips = append(ips, ip)
+	}
+	slices.SortFunc(ips, func(a, b string) int {
+		return ipRecords[a].lastFailure.Compare(ipRecords[b].lastFailure)
+	})
+	for _, ip := range ips[:maxIPRecords/10] {
+		delete(ipRecords, ip)
+	}
+}
@@ -0,0 +1,208 @@
+package oidc_client
+
+import (
+	"confkeeper/utils/config"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gookit/slog"
+)
+
+// provider 身份提供方的端点，来自 {issuer}/.well-known/openid-configuration
+type provider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+var (
+	httpClient = &http.Client{Timeout: 10 * time.Second}
+
+	// 端点发现成功后缓存 discoveryTTL，身份提供方不可用时下次登录重新获取
+	discovered   *provider
+	discoverTime time.Time
+	discoverLock sync.Mutex
+)
+
+// 端点缓存的有效期，过期后重新获取，身份提供方修改配置后无需重启
+const discoveryTTL = time.Hour
+
+// discover 获取身份提供方的端点
+func discover() (*provider, error) {
+	discoverLock.Lock()
+	defer discoverLock.Unlock()
+
+	if discovered != nil && time.Since(discoverTime) < discoveryTTL {
+		return discovered, nil
+	}
+
+	issuer := strings.TrimSuffix(config.Cfg.Oidc.Issuer, "/")
+	p := new(provider)
+	if err := getJSON(issuer+"/.well-known/openid-configuration", p); err != nil {
+		slog.Errorf("获取OIDC配置失败: %v", err)
+		return nil, fmt.Errorf("获取OIDC配置失败")
+	}
+	if strings.TrimSuffix(p.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("OIDC issuer 不匹配: %s", p.Issuer)
+	}
+	if p.AuthorizationEndpoint == "" || p.TokenEndpoint == "" || p.JwksURI == "" {
+		return nil, fmt.Errorf("OIDC配置缺少必要的端点")
+	}
+
+	discovered, discoverTime = p, time.Now()
+	return p, nil
+}
+
+// AuthCodeURL 生成跳转到身份提供方的授权地址，使用 PKCE(S256) 保护授权码
+func AuthCodeURL(state *LoginState) (string, error) {
+	p, err := discover()
+	if err != nil {
+		return "", err
+	}
+
+	scopes := config.Cfg.Oidc.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email"}
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", config.Cfg.Oidc.ClientID)
+	params.Set("redirect_uri", config.Cfg.Oidc.RedirectURL)
+	params.Set("scope", strings.Join(scopes, " "))
+	params.Set("state", state.State)
+	params.Set("nonce", state.Nonce)
+	params.Set("code_challenge", codeChallenge(state.CodeVerifier))
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return p.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange 使用授权码换取 ID Token 并校验，返回 ID Token 中的 claims
+func Exchange(code string, state *LoginState) (map[string]interface{}, error) {
+	p, err := discover()
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", config.Cfg.Oidc.RedirectURL)
+	form.Set("client_id", config.Cfg.Oidc.ClientID)
+	form.Set("code_verifier", state.CodeVerifier)
+
+	req, err := http.NewRequest(http.MethodPost, p.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if config.Cfg.Oidc.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(config.Cfg.Oidc.ClientID), url.QueryEscape(config.Cfg.Oidc.ClientSecret))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		slog.Errorf("OIDC授权码换取token失败: %v", err)
+		return nil, fmt.Errorf("OIDC授权码换取token失败")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	token := new(tokenResponse)
+	if err := json.Unmarshal(body, token); err != nil {
+		return nil, fmt.Errorf("OIDC token响应格式错误")
+	}
+	if resp.StatusCode != http.StatusOK || token.Error != "" {
+		return nil, fmt.Errorf("OIDC授权码换取token失败: %s %s", token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("OIDC token响应缺少 id_token")
+	}
+
+	return verifyIDToken(p, token.IDToken, state.Nonce)
+}
+
+// StringClaim 获取字符串类型的 claim
+func StringClaim(claims map[string]interface{}, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// GroupsClaim 获取用户组 claim，兼容数组和逗号分隔的字符串
+func GroupsClaim(claims map[string]interface{}, name string) []string {
+	var groups []string
+	switch value := claims[name].(type) {
+	case []interface{}:
+		for _, v := range value {
+			if group, ok := v.(string); ok && group != "" {
+				groups = append(groups, group)
+			}
+		}
+	case string:
+		for _, group := range strings.Split(value, ",") {
+			if group = strings.TrimSpace(group); group != "" {
+				groups = append(groups, group)
+			}
+		}
+	}
+	return groups
+}
+
+func getJSON(rawURL string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s 返回状态码 %d", rawURL, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+// randomString 生成 base64url 编码的随机字符串
+func randomString(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,271 @@
+package oidc_client
+
+import (
+	"confkeeper/utils/config"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// mockIssuer 本地模拟的身份提供方，实现端点发现、JWKS 和授权码换取 token
+type mockIssuer struct {
+	server *httptest.Server
+
+	mu        sync.Mutex
+	key       *rsa.PrivateKey
+	kid       string
+	challenge string
+	nonce     string
+	subject   string
+	jwksHits  int
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+	m := &mockIssuer{subject: "user-1"}
+	m.rotateKey(t, "key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 m.server.URL,
+			"authorization_endpoint": m.server.URL + "/authorize",
+			"token_endpoint":         m.server.URL + "/token",
+			"jwks_uri":               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.jwksHits++
+		writeJSON(w, map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": m.kid,
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if err := r.ParseForm(); err != nil || r.Form.Get("code") != "good-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		// PKCE: code_verifier 必须与授权请求中的 code_challenge 对应
+		if codeChallenge(r.Form.Get("code_verifier")) != m.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant", "error_description": "pkce"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":                m.server.URL,
+			"sub":                m.subject,
+			"aud":                config.Cfg.Oidc.ClientID,
+			"exp":                time.Now().Add(time.Minute).Unix(),
+			"iat":                time.Now().Unix(),
+			"nonce":              m.nonce,
+			"preferred_username": "alice",
+			"groups":             []string{"dev", "ops"},
+		})
+		token.Header["kid"] = m.kid
+		idToken, err := token.SignedString(m.key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]string{"id_token": idToken})
+	})
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+func (m *mockIssuer) rotateKey(t *testing.T, kid string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.mu.Lock()
+	m.key, m.kid = key, kid
+	m.mu.Unlock()
+}
+
+// authorize 模拟浏览器跳转到授权地址，记录 code_challenge 和 nonce
+func (m *mockIssuer) authorize(t *testing.T, state *LoginState) {
+	t.Helper()
+	authURL, err := AuthCodeURL(state)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("state") != state.State {
+		t.Fatalf("授权地址参数错误: %s", authURL)
+	}
+	m.mu.Lock()
+	m.challenge, m.nonce = q.Get("code_challenge"), q.Get("nonce")
+	m.mu.Unlock()
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func setupOidc(t *testing.T) *mockIssuer {
+	t.Helper()
+	m := newMockIssuer(t)
+
+	old := config.Cfg.Oidc
+	config.Cfg.Oidc = config.OidcConfig{
+		Enabled:     true,
+		Issuer:      m.server.URL,
+		ClientID:    "confkeeper",
+		RedirectURL: "http://localhost/api/user/oidc/callback",
+	}
+	resetCache()
+	t.Cleanup(func() {
+		config.Cfg.Oidc = old
+		resetCache()
+	})
+	return m
+}
+
+func resetCache() {
+	discoverLock.Lock()
+	discovered, discoverTime = nil, time.Time{}
+	discoverLock.Unlock()
+	keysLock.Lock()
+	publicKeys, keysJwksURI, keysLoadTime = nil, "", time.Time{}
+	keysLock.Unlock()
+}
+
+func login(t *testing.T, m *mockIssuer) (map[string]interface{}, error) {
+	t.Helper()
+	state, err := NewLoginState(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.authorize(t, state)
+	return Exchange("good-code", state)
+}
+
+func TestExchange(t *testing.T) {
+	m := setupOidc(t)
+
+	claims, err := login(t, m)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if StringClaim(claims, "sub") != "user-1" || StringClaim(claims, "iss") != m.server.URL {
+		t.Fatalf("claims 错误: %v", claims)
+	}
+	if StringClaim(claims, "preferred_username") != "alice" {
+		t.Fatalf("用户名错误: %v", claims)
+	}
+	if groups := GroupsClaim(claims, "groups"); len(groups) != 2 || groups[0] != "dev" {
+		t.Fatalf("用户组错误: %v", groups)
+	}
+}
+
+func TestExchangeRejectsWrongVerifierAndNonce(t *testing.T) {
+	m := setupOidc(t)
+
+	state, err := NewLoginState(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.authorize(t, state)
+
+	wrongVerifier := *state
+	wrongVerifier.CodeVerifier = "another-verifier"
+	if _, err := Exchange("good-code", &wrongVerifier); err == nil {
+		t.Fatal("code_verifier 错误时应该失败")
+	}
+
+	wrongNonce := *state
+	wrongNonce.Nonce = "another-nonce"
+	if _, err := Exchange("good-code", &wrongNonce); err == nil {
+		t.Fatal("nonce 不匹配时应该失败")
+	}
+
+	if _, err := Exchange("bad-code", state); err == nil {
+		t.Fatal("授权码错误时应该失败")
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	m := setupOidc(t)
+
+	if _, err := login(t, m); err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	// 轮换为新的 kid，超过最小刷新间隔后重新获取公钥
+	m.rotateKey(t, "key-2")
+	keysLock.Lock()
+	keysLoadTime = time.Now().Add(-keysRefreshInterval)
+	keysLock.Unlock()
+	if _, err := login(t, m); err != nil {
+		t.Fatalf("轮换 kid 后登录失败: %v", err)
+	}
+
+	// kid 不变但密钥更换，缓存过期后重新获取
+	m.rotateKey(t, "key-2")
+	if _, err := login(t, m); err == nil {
+		t.Fatal("缓存未过期时应该使用原来的公钥")
+	}
+	keysLock.Lock()
+	keysLoadTime = time.Now().Add(-keysTTL)
+	keysLock.Unlock()
+	if _, err := login(t, m); err != nil {
+		t.Fatalf("公钥缓存过期后登录失败: %v", err)
+	}
+
+	m.mu.Lock()
+	hits := m.jwksHits
+	m.mu.Unlock()
+	if hits != 3 {
+		t.Fatalf("JWKS 请求次数应为3，实际为 %d", hits)
+	}
+}
+
+func TestDiscoveryExpires(t *testing.T) {
+	m := setupOidc(t)
+
+	if _, err := discover(); err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	// 模拟缓存的端点已过时，缓存过期后应重新获取
+	discoverLock.Lock()
+	discovered.TokenEndpoint = "http://127.0.0.1:0/token"
+	discoverTime = time.Now().Add(-discoveryTTL)
+	discoverLock.Unlock()
+
+	p, err := discover()
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	if p.TokenEndpoint != m.server.URL+"/token" {
+		t.Fatalf("缓存过期后应重新获取端点: %s", p.TokenEndpoint)
+	}
+}
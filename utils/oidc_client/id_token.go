@@ -0,0 +1,174 @@
+package oidc_client
+
+import (
+	"confkeeper/utils/config"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gookit/slog"
+)
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+var (
+	// 身份提供方的签名公钥，kid -> 公钥；遇到未知 kid 或超过 keysTTL 时重新获取，应对密钥轮换
+	publicKeys   map[string]interface{}
+	keysJwksURI  string
+	keysLock     sync.Mutex
+	keysLoadTime time.Time
+)
+
+const (
+	// 两次重新获取公钥的最小间隔，避免伪造的 kid 导致频繁请求身份提供方
+	keysRefreshInterval = time.Minute
+	// 公钥缓存的有效期，过期后重新获取，身份提供方撤销的公钥不会一直有效
+	keysTTL = time.Hour
+)
+
+// verifyIDToken 校验 ID Token 的签名、issuer、audience、过期时间和 nonce
+func verifyIDToken(p *provider, rawIDToken string, nonce string) (map[string]interface{}, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return publicKey(p, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(p.Issuer),
+		jwt.WithAudience(config.Cfg.Oidc.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ID Token 校验失败: %v", err)
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, errors.New("ID Token nonce 不匹配")
+	}
+	// 存在多个 audience 时，azp 必须是本应用
+	if azp, ok := claims["azp"].(string); ok && azp != config.Cfg.Oidc.ClientID {
+		return nil, errors.New("ID Token azp 不匹配")
+	}
+	return claims, nil
+}
+
+// publicKey 按 kid 获取签名公钥，kid 为空时只有一个公钥才能使用
+func publicKey(p *provider, kid string) (interface{}, error) {
+	keysLock.Lock()
+	defer keysLock.Unlock()
+
+	// jwks_uri 变化时丢弃原来的公钥
+	if keysJwksURI != p.JwksURI {
+		publicKeys, keysJwksURI, keysLoadTime = nil, p.JwksURI, time.Time{}
+	}
+	if key := findKey(kid); key != nil && time.Since(keysLoadTime) < keysTTL {
+		return key, nil
+	}
+	if time.Since(keysLoadTime) < keysRefreshInterval {
+		return nil, fmt.Errorf("未找到签名公钥: %s", kid)
+	}
+
+	keysLoadTime = time.Now()
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := getJSON(p.JwksURI, &jwks); err != nil {
+		slog.Errorf("获取OIDC签名公钥失败: %v", err)
+		return nil, errors.New("获取OIDC签名公钥失败")
+	}
+
+	keys := make(map[string]interface{})
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			slog.Warnf("忽略无法解析的OIDC签名公钥 %s: %v", jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	publicKeys = keys
+
+	if key := findKey(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("未找到签名公钥: %s", kid)
+}
+
+func findKey(kid string) interface{} {
+	if kid == "" && len(publicKeys) == 1 {
+		for _, key := range publicKeys {
+			return key
+		}
+	}
+	return publicKeys[kid]
+}
+
+func (k *jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("不支持的曲线: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("公钥长度错误")
+		}
+		return ecdsa.ParseUncompressedPublicKey(curve, append(append([]byte{4}, x...), y...))
+	default:
+		return nil, fmt.Errorf("不支持的密钥类型: %s", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
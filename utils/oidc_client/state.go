@@ -0,0 +1,79 @@
+package oidc_client
+
+import (
+	"confkeeper/utils/config"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 完成身份提供方登录的时限
+const stateExpireTime = 10 * time.Minute
+
+// LoginState 一次授权码登录的临时状态，签名后保存在浏览器 cookie 中，多副本部署时无需共享存储
+type LoginState struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	RememberMe   bool   `json:"remember_me"`
+	jwt.RegisteredClaims
+}
+
+// NewLoginState 生成新的 state、nonce 和 PKCE code_verifier
+func NewLoginState(rememberMe bool) (*LoginState, error) {
+	state, err := randomString(24)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString(24)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginState{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		RememberMe:   rememberMe,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    config.Cfg.Server.Name,
+			Subject:   "oidc_state",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(stateExpireTime)),
+		},
+	}, nil
+}
+
+// Encode 签名后作为 cookie 的值
+func (s *LoginState) Encode() (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, s).SignedString([]byte(config.Cfg.Jwt.Secret))
+}
+
+// MaxAge cookie 的有效期(秒)
+func (s *LoginState) MaxAge() int {
+	return int(stateExpireTime.Seconds())
+}
+
+// ParseLoginState 校验 cookie 签名并与回调参数中的 state 比对
+func ParseLoginState(cookie string, state string) (*LoginState, error) {
+	loginState := new(LoginState)
+	_, err := jwt.ParseWithClaims(cookie, loginState, func(token *jwt.Token) (interface{}, error) {
+		return []byte(config.Cfg.Jwt.Secret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(config.Cfg.Server.Name),
+		jwt.WithSubject("oidc_state"),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, errors.New("登录状态已失效，请重新登录")
+	}
+	if state == "" || loginState.State != state {
+		return nil, errors.New("state 不匹配，请重新登录")
+	}
+	return loginState, nil
+}
@@ -0,0 +1,100 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2id 参数，参考 OWASP 推荐值(19MiB 内存，2 次迭代，1 个并行度)
+const (
+	argon2idMemory  = 19 * 1024
+	argon2idTime    = 2
+	argon2idThreads = 1
+	argon2idKeyLen  = 32
+	argon2idSaltLen = 16
+)
+
+type argon2idHasher struct{}
+
+// argon2idParams 哈希中记录的参数
+type argon2idParams struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+// Hash 生成 PHC 格式的哈希: $argon2id$v=19$m=19456,t=2,p=1$salt$key
+func (h *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2idSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argon2idTime, argon2idMemory, argon2idThreads, argon2idKeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argon2idMemory, argon2idTime, argon2idThreads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *argon2idHasher) Verify(hash string, password string) bool {
+	params, err := parseArgon2id(hash)
+	if err != nil {
+		return false
+	}
+
+	key := argon2.IDKey([]byte(password), params.salt, params.time, params.memory, params.threads, uint32(len(params.key)))
+	return subtle.ConstantTimeCompare(key, params.key) == 1
+}
+
+func (h *argon2idHasher) Match(hash string) bool {
+	return strings.HasPrefix(hash, "$argon2id$")
+}
+
+func (h *argon2idHasher) NeedsRehash(hash string) bool {
+	params, err := parseArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return params.memory < argon2idMemory || params.time < argon2idTime || len(params.key) < argon2idKeyLen
+}
+
+func parseArgon2id(hash string) (*argon2idParams, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, fmt.Errorf("argon2id 哈希格式错误")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, fmt.Errorf("argon2id 版本不支持")
+	}
+
+	params := new(argon2idParams)
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return nil, fmt.Errorf("argon2id 参数格式错误: %w", err)
+	}
+	if params.time == 0 || params.threads == 0 {
+		return nil, fmt.Errorf("argon2id 参数格式错误")
+	}
+
+	var err error
+	if params.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, err
+	}
+	if params.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return nil, err
+	}
+	if len(params.key) == 0 {
+		return nil, fmt.Errorf("argon2id 哈希格式错误")
+	}
+	return params, nil
+}
@@ -0,0 +1,30 @@
+package password
+
+import (
+	"confkeeper/utils"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// md5Hasher 旧版本使用的无盐 md5，只用于校验，校验通过后应升级为当前算法
+type md5Hasher struct{}
+
+func (h *md5Hasher) Hash(password string) (string, error) {
+	return utils.MD5(password), nil
+}
+
+func (h *md5Hasher) Verify(hash string, password string) bool {
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(utils.MD5(password))) == 1
+}
+
+func (h *md5Hasher) Match(hash string) bool {
+	if len(hash) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
+
+func (h *md5Hasher) NeedsRehash(hash string) bool {
+	return true
+}
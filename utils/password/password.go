@@ -0,0 +1,76 @@
+package password
+
+import (
+	"confkeeper/utils/config"
+	"errors"
+)
+
+// Hasher 密码哈希算法
+type Hasher interface {
+	// Hash 生成密码哈希
+	Hash(password string) (string, error)
+	// Verify 校验密码是否与哈希匹配
+	Verify(hash string, password string) bool
+	// Match 判断哈希是否由该算法生成
+	Match(hash string) bool
+	// NeedsRehash 判断哈希参数是否低于当前配置，需要重新生成
+	NeedsRehash(hash string) bool
+}
+
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// hashers 支持校验的全部算法，旧的 md5 只用于校验和迁移
+var hashers = map[string]Hasher{
+	AlgorithmArgon2id: &argon2idHasher{},
+	AlgorithmBcrypt:   &bcryptHasher{},
+	"md5":             &md5Hasher{},
+}
+
+// ErrUnknownAlgorithm 配置了不支持的哈希算法
+var ErrUnknownAlgorithm = errors.New("不支持的密码哈希算法")
+
+// current 返回当前配置的哈希算法，未配置时使用 argon2id
+func current() (Hasher, error) {
+	algorithm := config.Cfg.Password.Algorithm
+	if algorithm == "" {
+		algorithm = AlgorithmArgon2id
+	}
+	if algorithm != AlgorithmArgon2id && algorithm != AlgorithmBcrypt {
+		return nil, ErrUnknownAlgorithm
+	}
+	return hashers[algorithm], nil
+}
+
+// Hash 使用当前配置的算法生成密码哈希
+func Hash(password string) (string, error) {
+	hasher, err := current()
+	if err != nil {
+		return "", err
+	}
+	return hasher.Hash(password)
+}
+
+// Verify 校验密码，needsRehash 表示哈希来自旧算法或旧参数，应在校验通过后重新生成
+func Verify(hash string, password string) (ok bool, needsRehash bool) {
+	if hash == "" {
+		return false, false
+	}
+
+	currentHasher, err := current()
+	if err != nil {
+		return false, false
+	}
+	for _, hasher := range hashers {
+		if !hasher.Match(hash) {
+			continue
+		}
+		if !hasher.Verify(hash, password) {
+			return false, false
+		}
+		return true, hasher != currentHasher || hasher.NeedsRehash(hash)
+	}
+	return false, false
+}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceWildcard 匹配任意字符串
+const ResourceWildcard = "*"
+
+// Resource 权限资源 tenant:group:dataId，每一段都支持通配符 * (任意个字符) 和 ? (单个字符)
+// 省略的段等同于 *，只写 tenant 时表示整个命名空间，与旧版本的权限含义一致
+type Resource struct {
+	Tenant string
+	Group  string
+	DataId string
+}
+
+// ParseResource 解析权限资源，dataId 中可以包含冒号
+func ParseResource(resource string) (Resource, error) {
+	parts := strings.SplitN(resource, ":", 3)
+	for len(parts) < 3 {
+		parts = append(parts, ResourceWildcard)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return Resource{}, fmt.Errorf("权限资源格式错误，应为 tenant:group:dataId: %s", resource)
+		}
+	}
+	return Resource{Tenant: parts[0], Group: parts[1], DataId: parts[2]}, nil
+}
+
+func (r Resource) String() string {
+	return r.Tenant + ":" + r.Group + ":" + r.DataId
+}
+
+// MatchTenant 是否匹配命名空间
+func (r Resource) MatchTenant(tenant string) bool {
+	return GlobMatch(r.Tenant, tenant)
+}
+
+// Match 是否匹配命名空间下的某个配置
+func (r Resource) Match(tenant string, group string, dataId string) bool {
+	return GlobMatch(r.Tenant, tenant) && GlobMatch(r.Group, group) && GlobMatch(r.DataId, dataId)
+}
+
+// MatchAll 是否匹配命名空间下的所有配置
+func (r Resource) MatchAll() bool {
+	return r.Group == ResourceWildcard && r.DataId == ResourceWildcard
+}
+
+// Covers 是否包含命名空间下 group、dataId 通配符范围内的所有配置，不能确定时返回 false
+func (r Resource) Covers(tenant string, group string, dataId string) bool {
+	return GlobMatch(r.Tenant, tenant) && GlobCovers(r.Group, group) && GlobCovers(r.DataId, dataId)
+}
+
+// Overlaps 是否可能匹配命名空间下 group、dataId 通配符范围内的某个配置，不能确定时返回 true
+func (r Resource) Overlaps(tenant string, group string, dataId string) bool {
+	return GlobMatch(r.Tenant, tenant) && GlobOverlaps(r.Group, group) && GlobOverlaps(r.DataId, dataId)
+}
+
+// HasWildcard 是否包含通配符
+func HasWildcard(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?")
+}
+
+// GlobMatch 通配符匹配，* 匹配任意个字符(包括 / 和 :)，? 匹配单个字符
+func GlobMatch(pattern string, s string) bool {
+	p, str := []rune(pattern), []rune(s)
+	// 回溯到最近一个 * 的位置，让它多匹配一个字符
+	star, next := -1, 0
+	i, j := 0, 0
+	for j < len(str) {
+		switch {
+		case i < len(p) && (p[i] == '?' || p[i] == str[j]):
+			i++
+			j++
+		case i < len(p) && p[i] == '*':
+			star, next = i, j
+			i++
+		case star >= 0:
+			next++
+			i, j = star+1, next
+		default:
+			return false
+		}
+	}
+	for i < len(p) && p[i] == '*' {
+		i++
+	}
+	return i == len(p)
+}
+
+// GlobCovers pattern 是否匹配 filter 能匹配的所有字符串，只识别相同、* 和 前缀* 的情况，其余返回 false
+func GlobCovers(pattern string, filter string) bool {
+	if !HasWildcard(filter) {
+		return GlobMatch(pattern, filter)
+	}
+	if pattern == filter {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(pattern, "*")
+	if !ok || HasWildcard(prefix) {
+		return false
+	}
+	return strings.HasPrefix(literalPrefix(filter), prefix)
+}
+
+// GlobOverlaps 是否存在同时匹配 a 和 b 的字符串，只在通配符前的固定前缀不兼容时返回 false
+func GlobOverlaps(a string, b string) bool {
+	switch {
+	case !HasWildcard(a):
+		return GlobMatch(b, a)
+	case !HasWildcard(b):
+		return GlobMatch(a, b)
+	}
+	pa, pb := literalPrefix(a), literalPrefix(b)
+	return strings.HasPrefix(pa, pb) || strings.HasPrefix(pb, pa)
+}
+
+// literalPrefix 第一个通配符之前的固定部分
+func literalPrefix(pattern string) string {
+	if i := strings.IndexAny(pattern, "*?"); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"confkeeper/utils/config"
+	"slices"
+	"strings"
+)
+
+// MappedRoles 按 role_mapping 把外部身份源的用户组转换为角色，组名不区分大小写
+// 返回用户应有的角色和 role_mapping 管理的全部角色，用于同步时移除用户已不在其中的组对应的角色
+func MappedRoles(mappings []config.RoleMapping, groups []string) (roles []string, managedRoles []string) {
+	for _, mapping := range mappings {
+		if mapping.Group == "" || mapping.Role == "" {
+			continue
+		}
+		if !slices.Contains(managedRoles, mapping.Role) {
+			managedRoles = append(managedRoles, mapping.Role)
+		}
+		inGroup := slices.ContainsFunc(groups, func(group string) bool {
+			return strings.EqualFold(group, mapping.Group)
+		})
+		if inGroup && !slices.Contains(roles, mapping.Role) {
+			roles = append(roles, mapping.Role)
+		}
+	}
+	return roles, managedRoles
+}
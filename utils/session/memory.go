@@ -0,0 +1,140 @@
+package session
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// MemoryStore 进程内存会话存储，重启后会话失效，且不能在多副本间共享
+type MemoryStore struct {
+	// userid -> []会话ID，按创建顺序排列
+	tokens sync.Map
+	lock   sync.Mutex
+
+	// 会话ID -> 过期时间
+	revokedTokens sync.Map
+	// userid -> 吊销时间
+	revokedUsers sync.Map
+
+	// 会话ID -> 当前有效的 refresh token
+	refreshTokens map[string]*memoryRefresh
+	refreshLock   sync.Mutex
+}
+
+type memoryRefresh struct {
+	jti        string
+	expireTime time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{refreshTokens: make(map[string]*memoryRefresh)}
+}
+
+func (s *MemoryStore) Add(userid int, sessionID string, expireTime time.Time, maxSessions int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var tokens []string
+	if existingTokens, ok := s.tokens.Load(userid); ok {
+		// 复制切片以避免修改 Map 中的切片
+		tokens = slices.Clone(existingTokens.([]string))
+	}
+
+	// 添加新会话
+	tokens = append(tokens, sessionID)
+
+	// 强制限制：如果超过限制，删除最旧的
+	if len(tokens) > maxSessions {
+		tokens = tokens[len(tokens)-maxSessions:]
+	}
+
+	s.tokens.Store(userid, tokens)
+	return nil
+}
+
+func (s *MemoryStore) Exists(userid int, sessionID string) (bool, error) {
+	tokens, ok := s.tokens.Load(userid)
+	if !ok {
+		return false, nil
+	}
+	return slices.Contains(tokens.([]string), sessionID), nil
+}
+
+func (s *MemoryStore) Remove(userid int, sessionID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if existingTokens, ok := s.tokens.Load(userid); ok {
+		tokens := slices.DeleteFunc(slices.Clone(existingTokens.([]string)), func(t string) bool {
+			return t == sessionID
+		})
+		s.tokens.Store(userid, tokens)
+	}
+	return nil
+}
+
+func (s *MemoryStore) RemoveAll(userid int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.tokens.Delete(userid)
+	return nil
+}
+
+func (s *MemoryStore) Revoke(sessionID string, expireTime time.Time) error {
+	// 顺便清理已过期的黑名单
+	now := time.Now()
+	s.revokedTokens.Range(func(key, value any) bool {
+		if value.(time.Time).Before(now) {
+			s.revokedTokens.Delete(key)
+		}
+		return true
+	})
+
+	s.revokedTokens.Store(sessionID, expireTime)
+	return nil
+}
+
+func (s *MemoryStore) RevokeUser(userid int, revokeTime time.Time, expireTime time.Time) error {
+	s.revokedUsers.Store(userid, revokeTime)
+	return nil
+}
+
+func (s *MemoryStore) IsRevoked(userid int, sessionID string, issuedAt time.Time) (bool, error) {
+	if _, ok := s.revokedTokens.Load(sessionID); ok {
+		return true, nil
+	}
+	if revokeTime, ok := s.revokedUsers.Load(userid); ok {
+		return !issuedAt.After(revokeTime.(time.Time)), nil
+	}
+	return false, nil
+}
+
+func (s *MemoryStore) SaveRefresh(sessionID string, jti string, expireTime time.Time) error {
+	s.refreshLock.Lock()
+	defer s.refreshLock.Unlock()
+
+	// 顺便清理已过期的 refresh token
+	now := time.Now()
+	for id, refresh := range s.refreshTokens {
+		if refresh.expireTime.Before(now) {
+			delete(s.refreshTokens, id)
+		}
+	}
+
+	s.refreshTokens[sessionID] = &memoryRefresh{jti: jti, expireTime: expireTime}
+	return nil
+}
+
+func (s *MemoryStore) RotateRefresh(sessionID string, oldJti string, newJti string) (bool, error) {
+	s.refreshLock.Lock()
+	defer s.refreshLock.Unlock()
+
+	refresh, ok := s.refreshTokens[sessionID]
+	if !ok || refresh.jti != oldJti || refresh.expireTime.Before(time.Now()) {
+		return false, nil
+	}
+	refresh.jti = newJti
+	return true, nil
+}
@@ -0,0 +1,166 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisKeyPrefix             = "confkeeper:session:"
+	redisRevokedTokenKeyPrefix = "confkeeper:revoked:token:"
+	redisRevokedUserKeyPrefix  = "confkeeper:revoked:user:"
+	redisRefreshKeyPrefix      = "confkeeper:refresh:"
+	redisTimeout               = 3 * time.Second
+)
+
+// redisAddScript 原子地添加会话并淘汰最早的会话
+// 会话保存在有序集合中，score 为创建时间；key 的过期时间取所有会话中最晚的过期时间
+var redisAddScript = redis.NewScript(`
+redis.call('ZADD', KEYS[1], ARGV[1], ARGV[2])
+redis.call('ZREMRANGEBYRANK', KEYS[1], 0, -(tonumber(ARGV[3]) + 1))
+if redis.call('PTTL', KEYS[1]) < tonumber(ARGV[4]) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[4])
+end
+return 1
+`)
+
+// redisRotateScript 当前 refresh token 与 ARGV[1] 一致时替换为 ARGV[2]，保留原有过期时间
+var redisRotateScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl <= 0 then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ttl)
+return 1
+`)
+
+// RedisStore 基于 redis 的会话存储，多副本共享同一个 redis 时会话互通
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(addr string, password string, db int) (*RedisStore, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return &RedisStore{client: client}, nil
+}
+
+func (s *RedisStore) Add(userid int, sessionID string, expireTime time.Time, maxSessions int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	ttl := time.Until(expireTime).Milliseconds()
+	if ttl <= 0 {
+		return nil
+	}
+	return redisAddScript.Run(ctx, s.client, []string{redisKey(userid)},
+		time.Now().UnixMicro(), HashToken(sessionID), maxSessions, ttl).Err()
+}
+
+func (s *RedisStore) Exists(userid int, sessionID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	err := s.client.ZScore(ctx, redisKey(userid), HashToken(sessionID)).Err()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *RedisStore) Remove(userid int, sessionID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	return s.client.ZRem(ctx, redisKey(userid), HashToken(sessionID)).Err()
+}
+
+func (s *RedisStore) RemoveAll(userid int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	return s.client.Del(ctx, redisKey(userid)).Err()
+}
+
+func (s *RedisStore) Revoke(sessionID string, expireTime time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	ttl := time.Until(expireTime)
+	if ttl <= 0 {
+		return nil
+	}
+	return s.client.Set(ctx, redisRevokedTokenKeyPrefix+sessionID, 1, ttl).Err()
+}
+
+func (s *RedisStore) RevokeUser(userid int, revokeTime time.Time, expireTime time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	ttl := time.Until(expireTime)
+	if ttl <= 0 {
+		return nil
+	}
+	return s.client.Set(ctx, redisRevokedUserKeyPrefix+strconv.Itoa(userid), revokeTime.UnixMilli(), ttl).Err()
+}
+
+func (s *RedisStore) IsRevoked(userid int, sessionID string, issuedAt time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	values, err := s.client.MGet(ctx, redisRevokedTokenKeyPrefix+sessionID, redisRevokedUserKeyPrefix+strconv.Itoa(userid)).Result()
+	if err != nil {
+		return false, err
+	}
+	if values[0] != nil {
+		return true, nil
+	}
+	if values[1] != nil {
+		revokeTime, err := strconv.ParseInt(values[1].(string), 10, 64)
+		if err != nil {
+			return false, err
+		}
+		return issuedAt.UnixMilli() <= revokeTime, nil
+	}
+	return false, nil
+}
+
+func (s *RedisStore) SaveRefresh(sessionID string, jti string, expireTime time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	ttl := time.Until(expireTime)
+	if ttl <= 0 {
+		return nil
+	}
+	return s.client.Set(ctx, redisRefreshKeyPrefix+HashToken(sessionID), jti, ttl).Err()
+}
+
+func (s *RedisStore) RotateRefresh(sessionID string, oldJti string, newJti string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	rotated, err := redisRotateScript.Run(ctx, s.client, []string{redisRefreshKeyPrefix + HashToken(sessionID)}, oldJti, newJti).Int()
+	return rotated == 1, err
+}
+
+func redisKey(userid int) string {
+	return redisKeyPrefix + strconv.Itoa(userid)
+}
@@ -0,0 +1,78 @@
+package session
+
+import (
+	"confkeeper/utils/config"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/gookit/slog"
+)
+
+// Store 登录会话存储，开启 jwt.enable_memory 后只有存储中的会话签发的 token 有效
+// 会话ID为一次登录签发的 token 家族ID，刷新 token 时保持不变
+type Store interface {
+	// Add 保存用户的新会话，会话数超过 maxSessions 时按创建顺序淘汰最早的会话
+	Add(userid int, sessionID string, expireTime time.Time, maxSessions int) error
+	// Exists 判断会话是否仍然有效
+	Exists(userid int, sessionID string) (bool, error)
+	// Remove 删除用户的单个会话
+	Remove(userid int, sessionID string) error
+	// RemoveAll 删除用户的所有会话
+	RemoveAll(userid int) error
+
+	// 未启用会话存储时，通过黑名单吊销 token
+
+	// Revoke 将会话ID加入黑名单，保留到 expireTime
+	Revoke(sessionID string, expireTime time.Time) error
+	// RevokeUser 吊销用户在 revokeTime 及之前签发的所有 token，记录保留到 expireTime
+	RevokeUser(userid int, revokeTime time.Time, expireTime time.Time) error
+	// IsRevoked 判断 token 是否已被吊销
+	IsRevoked(userid int, sessionID string, issuedAt time.Time) (bool, error)
+
+	// SaveRefresh 记录会话当前有效的 refresh token
+	SaveRefresh(sessionID string, jti string, expireTime time.Time) error
+	// RotateRefresh 当前有效的 refresh token 为 oldJti 时替换为 newJti 并返回 true，否则说明 refresh token 被重复使用
+	RotateRefresh(sessionID string, oldJti string, newJti string) (bool, error)
+}
+
+const (
+	StoreMemory = "memory"
+	StoreDB     = "db"
+	StoreRedis  = "redis"
+)
+
+// Default 当前使用的会话存储，默认为进程内存
+var Default Store = NewMemoryStore()
+
+// Init 根据 jwt.session_store 初始化会话存储，db 存储由调用方传入以复用数据库连接
+func Init(dbStore Store) error {
+	if config.Cfg.Jwt.SessionStore == "" {
+		config.Cfg.Jwt.SessionStore = StoreMemory
+	}
+
+	switch config.Cfg.Jwt.SessionStore {
+	case StoreMemory:
+		Default = NewMemoryStore()
+	case StoreDB:
+		Default = dbStore
+	case StoreRedis:
+		store, err := NewRedisStore(config.Cfg.Redis.Addr, config.Cfg.Redis.Password, config.Cfg.Redis.DB)
+		if err != nil {
+			return fmt.Errorf("连接redis失败: %w", err)
+		}
+		Default = store
+	default:
+		return fmt.Errorf("不支持的会话存储: %s", config.Cfg.Jwt.SessionStore)
+	}
+
+	slog.Infof("当前会话存储为%s", config.Cfg.Jwt.SessionStore)
+	return nil
+}
+
+// HashToken 持久化存储只保存会话ID的 sha256
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,234 @@
+package webhook_client
+
+import (
+	"confkeeper/biz/dal"
+	"confkeeper/biz/model"
+	"confkeeper/utils"
+	"confkeeper/utils/config"
+	"confkeeper/utils/event_bus"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gookit/slog"
+)
+
+// EventTest 手动触发的测试事件
+const EventTest = "test"
+
+const (
+	// 事件通道和投递队列的缓冲，批量导入时会在短时间内产生大量事件
+	eventBufferSize = 1024
+	// 投递的并发数，每个 Webhook 的一次投递(包括重试)占用一个
+	workerCount = 16
+	// 投递记录中保存的响应内容长度
+	maxResponseLen = 1000
+)
+
+// task 一次待投递的事件
+type task struct {
+	hook  *model.Webhook
+	event event_bus.ConfigEvent
+}
+
+var (
+	httpClient = newHTTPClient()
+	tasks      = make(chan task, eventBufferSize)
+)
+
+// Start 订阅配置变更事件，投递到命名空间下匹配的 Webhook
+func Start() {
+	timeout := config.Cfg.Webhook.Timeout
+	if timeout <= 0 {
+		timeout = 5
+	}
+	httpClient.Timeout = time.Duration(timeout) * time.Second
+	for _, cidr := range config.Cfg.Webhook.AllowedNetworks {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			slog.Errorf("忽略格式错误的 allowed_networks: %s", cidr)
+		}
+	}
+
+	for i := 0; i < workerCount; i++ {
+		go func() {
+			for t := range tasks {
+				deliver(t.hook, t.event)
+			}
+		}()
+	}
+
+	events, _ := event_bus.SubscribeWithBuffer(eventBufferSize)
+	go func() {
+		for event := range events {
+			dispatch(event)
+		}
+	}()
+	slog.Info("Webhook 投递已启动")
+}
+
+// dispatch 查询命名空间下匹配事件的 Webhook，加入投递队列，队列已满时丢弃
+func dispatch(event event_bus.ConfigEvent) {
+	hooks, err := dal.GetEnabledWebhooksByTenant(event.TenantID)
+	if err != nil {
+		slog.Errorf("查询Webhook失败: %v", err)
+		return
+	}
+	for _, hook := range hooks {
+		if !Match(hook, event) {
+			continue
+		}
+		select {
+		case tasks <- task{hook: hook, event: event}:
+		default:
+			slog.Warnf("Webhook投递队列已满，丢弃事件: webhook_id=%d, %s %s/%s/%s", hook.ID, event.Type, event.TenantID, event.GroupID, event.DataID)
+		}
+	}
+}
+
+// Match Webhook 是否订阅了该事件
+func Match(hook *model.Webhook, event event_bus.ConfigEvent) bool {
+	if hook.TenantID != event.TenantID {
+		return false
+	}
+	if hook.Events != "" && !containsEvent(hook.Events, event.Type) {
+		return false
+	}
+	if hook.GroupFilter != "" && !utils.GlobMatch(hook.GroupFilter, event.GroupID) {
+		return false
+	}
+	if hook.DataIdFilter != "" && !utils.GlobMatch(hook.DataIdFilter, event.DataID) {
+		return false
+	}
+	return true
+}
+
+func containsEvent(events string, eventType string) bool {
+	for _, e := range strings.Split(events, ",") {
+		if strings.TrimSpace(e) == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// deliver 投递事件，失败时按退避间隔重试，每次尝试的结果记录在同一条投递记录中
+func deliver(hook *model.Webhook, event event_bus.ConfigEvent) {
+	delivery := newDelivery(hook, event)
+	if err := dal.CreateWebhookDelivery(delivery); err != nil {
+		slog.Errorf("记录Webhook投递失败: %v", err)
+		return
+	}
+
+	interval := time.Duration(config.Cfg.Webhook.RetryInterval) * time.Second
+	if interval <= 0 {
+		interval = time.Second
+	}
+	for attempt := 0; attempt <= config.Cfg.Webhook.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(interval << (attempt - 1))
+		}
+		attemptDelivery(hook, event, delivery)
+		if delivery.Success {
+			return
+		}
+	}
+	slog.Warnf("Webhook投递失败: webhook_id=%d, delivery_id=%d, %s", hook.ID, delivery.ID, delivery.Error)
+}
+
+// Test 向 Webhook 发送一次测试事件，不重试，返回投递记录
+func Test(hook *model.Webhook) (*model.WebhookDelivery, error) {
+	event := event_bus.ConfigEvent{
+		Type:     EventTest,
+		TenantID: hook.TenantID,
+		GroupID:  hook.GroupFilter,
+		DataID:   hook.DataIdFilter,
+		Time:     time.Now(),
+	}
+	delivery := newDelivery(hook, event)
+	if err := dal.CreateWebhookDelivery(delivery); err != nil {
+		return nil, err
+	}
+	attemptDelivery(hook, event, delivery)
+	return delivery, nil
+}
+
+func newDelivery(hook *model.Webhook, event event_bus.ConfigEvent) *model.WebhookDelivery {
+	return &model.WebhookDelivery{
+		WebhookID: hook.ID,
+		Event:     event.Type,
+		TenantID:  event.TenantID,
+		GroupID:   event.GroupID,
+		DataID:    event.DataID,
+		Version:   event.Version,
+	}
+}
+
+// attemptDelivery 发送一次请求并更新投递记录
+func attemptDelivery(hook *model.Webhook, event event_bus.ConfigEvent, delivery *model.WebhookDelivery) {
+	statusCode, response, err := send(hook, event, delivery.ID)
+
+	delivery.Attempts++
+	delivery.StatusCode = statusCode
+	delivery.Response = response
+	delivery.Success = err == nil
+	delivery.Error = ""
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+	if err := dal.UpdateWebhookDelivery(delivery); err != nil {
+		slog.Errorf("更新Webhook投递记录失败: %v", err)
+	}
+}
+
+// send 按 Webhook 的消息格式构造并发送请求，返回状态码和响应内容
+func send(hook *model.Webhook, event event_bus.ConfigEvent, deliveryId uint) (int, string, error) {
+	req, err := newRequest(hook, event, deliveryId)
+	if err != nil {
+		return 0, "", err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body := readResponse(resp)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, body, fmt.Errorf("响应状态码 %d", resp.StatusCode)
+	}
+	if err := checkResponse(hook.Kind, body); err != nil {
+		return resp.StatusCode, body, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+// ValidateURL 只允许 http 和 https 地址，主机为IP时检查是否为内网地址，域名在建立连接时检查
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return errors.New("回调地址格式错误")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("回调地址只支持 http 和 https")
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil {
+		return checkIP(ip)
+	}
+	return nil
+}
+
+// MaskURL 只保留协议和主机，用于没有管理权限的用户查看和审计日志，聊天机器人的令牌在路径或参数中
+func MaskURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "***"
+	}
+	if u.Path == "" && u.RawQuery == "" {
+		return u.Scheme + "://" + u.Host
+	}
+	return u.Scheme + "://" + u.Host + "/***"
+}
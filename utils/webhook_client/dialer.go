@@ -0,0 +1,88 @@
+package webhook_client
+
+import (
+	"confkeeper/utils/config"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// 最多跟随的重定向次数
+const maxRedirects = 3
+
+// reservedNetworks 标准库没有归类的保留地址段：本网络、运营商级NAT、协议分配、基准测试和保留地址
+var reservedNetworks = mustParseCIDRs("0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15", "240.0.0.0/4")
+
+// newHTTPClient 投递使用的客户端，建立连接前检查目标地址，不使用环境变量中的代理
+func newHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: dialControl,
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: checkRedirect,
+	}
+}
+
+// dialControl 在域名解析之后、建立连接之前检查地址，避免域名解析或重定向到内网地址
+func dialControl(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("回调地址解析错误: %s", address)
+	}
+	return checkIP(ip)
+}
+
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return errors.New("重定向次数过多")
+	}
+	return ValidateURL(req.URL.String())
+}
+
+// checkIP 不允许访问内网、回环、链路本地、组播和保留地址，allowed_networks 中的地址除外
+func checkIP(ip net.IP) error {
+	for _, cidr := range config.Cfg.Webhook.AllowedNetworks {
+		// 格式错误的地址段在启动时打印日志
+		if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(ip) {
+			return nil
+		}
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("不允许回调内网地址: %s", ip)
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return fmt.Errorf("不允许回调保留地址: %s", ip)
+		}
+	}
+	return nil
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,165 @@
+package webhook_client
+
+import (
+	"bytes"
+	"confkeeper/biz/model"
+	"confkeeper/utils/event_bus"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Payload generic 格式的请求体
+type Payload struct {
+	Event    string `json:"event"`
+	TenantID string `json:"tenant_id"`
+	GroupID  string `json:"group_id"`
+	DataID   string `json:"data_id"`
+	Version  int    `json:"version"`
+	Md5      string `json:"md5"`
+	Author   string `json:"author"`
+	Time     string `json:"time"`
+}
+
+var eventNames = map[string]string{
+	event_bus.EventPublish: "发布",
+	event_bus.EventDelete:  "删除",
+	event_bus.EventBeta:    "灰度变更",
+	EventTest:              "测试",
+}
+
+// newRequest 构造请求
+// generic 在 X-Confkeeper-Signature 中携带请求体的 HMAC-SHA256 签名，钉钉和飞书按各自的加签方式签名
+func newRequest(hook *model.Webhook, event event_bus.ConfigEvent, deliveryId uint) (*http.Request, error) {
+	targetURL := hook.URL
+	var body interface{}
+
+	switch hook.Kind {
+	case model.WebhookKindSlack:
+		body = map[string]string{"text": message(event)}
+	case model.WebhookKindDingtalk:
+		body = map[string]interface{}{
+			"msgtype": "text",
+			"text":    map[string]string{"content": message(event)},
+		}
+		if hook.Secret != "" {
+			timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+			sign := hmacBase64([]byte(hook.Secret), timestamp+"\n"+hook.Secret)
+			targetURL = appendQuery(targetURL, url.Values{"timestamp": {timestamp}, "sign": {sign}})
+		}
+	case model.WebhookKindFeishu:
+		msg := map[string]interface{}{
+			"msg_type": "text",
+			"content":  map[string]string{"text": message(event)},
+		}
+		if hook.Secret != "" {
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			msg["timestamp"] = timestamp
+			msg["sign"] = hmacBase64([]byte(timestamp+"\n"+hook.Secret), "")
+		}
+		body = msg
+	default:
+		body = &Payload{
+			Event:    event.Type,
+			TenantID: event.TenantID,
+			GroupID:  event.GroupID,
+			DataID:   event.DataID,
+			Version:  event.Version,
+			Md5:      event.Md5,
+			Author:   event.Author,
+			Time:     event.Time.Format(time.RFC3339),
+		}
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, targetURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Confkeeper-Webhook")
+	req.Header.Set("X-Confkeeper-Event", event.Type)
+	req.Header.Set("X-Confkeeper-Delivery", strconv.FormatUint(uint64(deliveryId), 10))
+	if hook.Secret != "" && (hook.Kind == "" || hook.Kind == model.WebhookKindGeneric) {
+		req.Header.Set("X-Confkeeper-Signature", "sha256="+Sign(hook.Secret, data))
+	}
+	return req, nil
+}
+
+// Sign 计算 generic 请求体的签名，接收方使用相同的密钥计算后比对
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func hmacBase64(key []byte, data string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func appendQuery(rawURL string, values url.Values) string {
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return rawURL + separator + values.Encode()
+}
+
+// message 聊天机器人的消息内容
+func message(event event_bus.ConfigEvent) string {
+	name, ok := eventNames[event.Type]
+	if !ok {
+		name = event.Type
+	}
+	lines := []string{
+		fmt.Sprintf("配置%s: %s / %s / %s", name, event.TenantID, event.GroupID, event.DataID),
+	}
+	if event.Version > 0 {
+		lines = append(lines, fmt.Sprintf("版本: %d", event.Version))
+	}
+	if event.Author != "" {
+		lines = append(lines, "修改人: "+event.Author)
+	}
+	lines = append(lines, "时间: "+event.Time.Format("2006-01-02 15:04:05"))
+	return strings.Join(lines, "\n")
+}
+
+func readResponse(resp *http.Response) string {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseLen))
+	return string(data)
+}
+
+// checkResponse 钉钉和飞书在签名错误等情况下同样返回200，需要检查响应中的错误码
+func checkResponse(kind string, body string) error {
+	var result struct {
+		ErrCode *int   `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+		Code    *int   `json:"code"`
+		Msg     string `json:"msg"`
+	}
+	switch kind {
+	case model.WebhookKindDingtalk:
+		if json.Unmarshal([]byte(body), &result) == nil && result.ErrCode != nil && *result.ErrCode != 0 {
+			return fmt.Errorf("钉钉返回错误: %d %s", *result.ErrCode, result.ErrMsg)
+		}
+	case model.WebhookKindFeishu:
+		if json.Unmarshal([]byte(body), &result) == nil && result.Code != nil && *result.Code != 0 {
+			return fmt.Errorf("飞书返回错误: %d %s", *result.Code, result.Msg)
+		}
+	}
+	return nil
+}